@@ -1,18 +1,26 @@
 module github.com/asgardeo/mcp
 
-go 1.23
+go 1.23.0
 
 toolchain go1.23.8
 
 require (
 	github.com/asgardeo/go v0.0.17
-	github.com/mark3labs/mcp-go v0.21.1
+	github.com/golang-jwt/jwt/v5 v5.2.2
+	github.com/mark3labs/mcp-go v0.43.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/apapsch/go-jsonmerge/v2 v2.0.0 // indirect
+	github.com/bahlo/generic-list-go v0.2.0 // indirect
+	github.com/buger/jsonparser v1.1.1 // indirect
 	github.com/google/uuid v1.6.0 // indirect
+	github.com/invopop/jsonschema v0.13.0 // indirect
+	github.com/mailru/easyjson v0.7.7 // indirect
 	github.com/oapi-codegen/runtime v1.1.1 // indirect
+	github.com/spf13/cast v1.7.1 // indirect
+	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
 	github.com/yosida95/uritemplate/v3 v3.0.2 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
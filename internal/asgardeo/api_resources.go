@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import (
+	"net/url"
+
+	"github.com/asgardeo/go/pkg/api_resource"
+)
+
+// APIResourceCursors returns the "after" cursor of the next page and the "before" cursor of the
+// previous page of an API resource listing, taken from its pagination links. A cursor is empty
+// when there is no such page.
+func APIResourceCursors(resp *api_resource.APIResourceListResponseModel) (next, previous string) {
+	for _, link := range resp.Links {
+		if link.Rel == nil || link.Href == nil {
+			continue
+		}
+		href, err := url.Parse(*link.Href)
+		if err != nil {
+			continue
+		}
+		switch *link.Rel {
+		case "next":
+			next = href.Query().Get("after")
+		case "previous", "prev":
+			previous = href.Query().Get("before")
+		}
+	}
+	return next, previous
+}
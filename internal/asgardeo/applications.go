@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/asgardeo/go/pkg/sdk"
+)
+
+// ApplicationListParams selects a page of the application listing.
+type ApplicationListParams struct {
+	Limit  int
+	Offset int
+	// Filter is a SCIM style filter expression, e.g. "name sw Pet".
+	Filter string
+}
+
+// ApplicationListItem is an application in the application listing.
+type ApplicationListItem struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ClientID    string `json:"clientId"`
+	Issuer      string `json:"issuer"`
+	TemplateID  string `json:"templateId"`
+	Description string `json:"description"`
+	Self        string `json:"self"`
+}
+
+// ApplicationList is a page of the application listing.
+type ApplicationList struct {
+	TotalResults int                   `json:"totalResults"`
+	StartIndex   int                   `json:"startIndex"`
+	Count        int                   `json:"count"`
+	Applications []ApplicationListItem `json:"applications"`
+}
+
+// ListApplications lists the applications with the application management API, which unlike the
+// SDK supports filtering and returns the client ID and template of each application.
+func ListApplications(ctx context.Context, client *sdk.Client, params ApplicationListParams) (*ApplicationList, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(params.Limit))
+	query.Set("offset", strconv.Itoa(params.Offset))
+	if params.Filter != "" {
+		query.Set("filter", params.Filter)
+	}
+
+	var list ApplicationList
+	if err := CallManagementAPI(ctx, client, http.MethodGet, "/applications?"+query.Encode(), nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// InboundProtocolPaths maps the inbound protocol types of an application to the path of their
+// configuration under /applications/{id}/inbound-protocols.
+var InboundProtocolPaths = map[string]string{
+	"oauth2":     "oidc",
+	"samlsso":    "saml",
+	"passivests": "passive-sts",
+	"wstrust":    "ws-trust",
+}
+
+// GetApplication returns the application with the given ID as returned by the application
+// management API, including its claim configuration, authentication sequence, advanced
+// configurations, associated roles and the list of its inbound protocols.
+func GetApplication(ctx context.Context, client *sdk.Client, id string) (map[string]any, error) {
+	app := map[string]any{}
+	if err := CallManagementAPI(ctx, client, http.MethodGet, "/applications/"+url.PathEscape(id), nil, &app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// GetInboundProtocol returns the configuration of an inbound protocol of the application. The
+// protocol is the path segment of the protocol, e.g. "oidc" or "saml".
+func GetInboundProtocol(ctx context.Context, client *sdk.Client, id, protocol string) (map[string]any, error) {
+	config := map[string]any{}
+	path := "/applications/" + url.PathEscape(id) + "/inbound-protocols/" + protocol
+	if err := CallManagementAPI(ctx, client, http.MethodGet, path, nil, &config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// UpdateInboundProtocol replaces the configuration of an inbound protocol of the application,
+// e.g. "oidc". Read the configuration with GetInboundProtocol and change it to keep the other settings.
+func UpdateInboundProtocol(ctx context.Context, client *sdk.Client, id, protocol string, config map[string]any) error {
+	path := "/applications/" + url.PathEscape(id) + "/inbound-protocols/" + protocol
+	return CallManagementAPI(ctx, client, http.MethodPut, path, config, nil)
+}
+
+// GetAuthorizedAPIs returns the API resources authorized to the application, with their scopes.
+func GetAuthorizedAPIs(ctx context.Context, client *sdk.Client, id string) ([]any, error) {
+	apis := []any{}
+	if err := CallManagementAPI(ctx, client, http.MethodGet, "/applications/"+url.PathEscape(id)+"/authorized-apis", nil, &apis); err != nil {
+		return nil, err
+	}
+	return apis, nil
+}
+
+// FindApplicationID returns the ID of the application with the given name or client ID, exactly
+// one of which must be set.
+func FindApplicationID(ctx context.Context, client *sdk.Client, name, clientID string) (string, error) {
+	filter, description := "name eq "+name, fmt.Sprintf("name '%s'", name)
+	if clientID != "" {
+		filter, description = "clientId eq "+clientID, fmt.Sprintf("client ID '%s'", clientID)
+	}
+	list, err := ListApplications(ctx, client, ApplicationListParams{Limit: 2, Filter: filter})
+	if err != nil {
+		return "", err
+	}
+	switch len(list.Applications) {
+	case 0:
+		return "", fmt.Errorf("application with %s not found", description)
+	case 1:
+		return list.Applications[0].ID, nil
+	}
+	ids := make([]string, len(list.Applications))
+	for i, app := range list.Applications {
+		ids[i] = app.ID
+	}
+	return "", fmt.Errorf("more than one application has %s: %s; use the application ID instead", description, strings.Join(ids, ", "))
+}
+
+// CreateApplication creates an application from the application model of the management API,
+// e.g. with an inboundProtocolConfiguration, and returns its ID.
+func CreateApplication(ctx context.Context, client *sdk.Client, app map[string]any) (string, error) {
+	header, err := callManagementAPI(ctx, client, http.MethodPost, "/applications", app, nil)
+	if err != nil {
+		return "", err
+	}
+	location := header.Get("Location")
+	if location == "" {
+		return "", fmt.Errorf("location header is missing in the response")
+	}
+	return path.Base(strings.TrimSuffix(location, "/")), nil
+}
+
+// PatchApplication changes the top level settings of the application, e.g. its description or
+// advancedConfigurations. Settings missing in the patch are kept.
+func PatchApplication(ctx context.Context, client *sdk.Client, id string, patch map[string]any) error {
+	return CallManagementAPI(ctx, client, http.MethodPatch, "/applications/"+url.PathEscape(id), patch, nil)
+}
+
+// DeleteApplication deletes the application with the given ID.
+func DeleteApplication(ctx context.Context, client *sdk.Client, id string) error {
+	return CallManagementAPI(ctx, client, http.MethodDelete, "/applications/"+url.PathEscape(id), nil, nil)
+}
+
+// GetSharedOrganizations returns the organizations the application is shared with.
+func GetSharedOrganizations(ctx context.Context, client *sdk.Client, id string) ([]any, error) {
+	var resp struct {
+		Organizations []any `json:"organizations"`
+	}
+	path := "/organizations/root/applications/" + url.PathEscape(id) + "/shared-organizations"
+	if err := CallManagementAPI(ctx, client, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Organizations == nil {
+		resp.Organizations = []any{}
+	}
+	return resp.Organizations, nil
+}
+
+// RegenerateClientSecret generates a new client secret for the OIDC configuration of the application
+// and returns the updated configuration. The previous secret stops working immediately.
+func RegenerateClientSecret(ctx context.Context, client *sdk.Client, id string) (map[string]any, error) {
+	return oidcAction(ctx, client, id, "regenerate-secret")
+}
+
+// RevokeOIDC revokes the OIDC configuration of the application, invalidating its client secret and tokens.
+func RevokeOIDC(ctx context.Context, client *sdk.Client, id string) error {
+	_, err := oidcAction(ctx, client, id, "revoke")
+	return err
+}
+
+// ReactivateOIDC reactivates a revoked OIDC configuration of the application. The server issues a
+// new client secret, which is part of the returned configuration.
+func ReactivateOIDC(ctx context.Context, client *sdk.Client, id string) (map[string]any, error) {
+	return oidcAction(ctx, client, id, "reactivate")
+}
+
+func oidcAction(ctx context.Context, client *sdk.Client, id, action string) (map[string]any, error) {
+	var resp map[string]any
+	path := "/applications/" + url.PathEscape(id) + "/inbound-protocols/oidc/" + action
+	if err := CallManagementAPI(ctx, client, http.MethodPost, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = map[string]any{}
+	}
+	return resp, nil
+}
@@ -2,43 +2,255 @@ package asgardeo
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/asgardeo/go/pkg/config"
 	"github.com/asgardeo/go/pkg/sdk"
+	"github.com/asgardeo/mcp/internal/auth"
 	internal_config "github.com/asgardeo/mcp/internal/config"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+// initRetryInterval is the minimum time between two attempts to initialize a client after a failure.
+const initRetryInterval = 5 * time.Second
+
+var (
+	// ErrNotConfigured is returned when the connection settings are missing or invalid.
+	ErrNotConfigured = errors.New("not configured")
+	// ErrUnreachable is returned when an access token cannot be obtained from the server.
+	ErrUnreachable = errors.New("unreachable")
+	// ErrUnknownProfile is returned when a request selects a profile that is not configured.
+	ErrUnknownProfile = errors.New("unknown profile")
+)
+
+// poolEntry holds the client of one profile, the initialization in progress, or the error of its
+// last initialization attempt.
+type poolEntry struct {
+	client  *sdk.Client
+	init    *flight[*sdk.Client]
+	err     error
+	triedAt time.Time
+}
+
+type profileKey struct{}
+
 var (
-	clientInstance *sdk.Client
-	once           sync.Once
-	initErr        error
+	clientMu        sync.Mutex
+	profiles        map[string]internal_config.Profile
+	defaultProfile  string
+	pool            = map[string]*poolEntry{}
+	sessionProfiles = map[string]string{}
 )
 
 // NewClient initializes an Asgardeo management client with client credentials.
-func NewClient(ctx context.Context, baseURL, clientID, clientSecret string, certPath *string) (*sdk.Client, error) {
+func NewClient(ctx context.Context, baseURL, clientID, clientSecret string, certPath *string, timeout time.Duration) (*sdk.Client, error) {
 
 	cfg := config.DefaultClientConfig().
 		WithBaseURL(baseURL).
-		WithTimeout(10*time.Second).
+		WithTimeout(timeout).
 		WithClientCredentials(clientID, clientSecret).
 		WithCertificatePath(certPath)
 
 	return sdk.New(cfg)
 }
 
-// GetClient returns the singleton Asgardeo client.
+// WithProfile returns a copy of the context that selects the named profile for the request.
+func WithProfile(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, profileKey{}, strings.ToLower(name))
+}
+
+// GetClientInstance returns the Asgardeo client to serve the request with. The profile is taken
+// from the request, then from the session default and finally from the configured default.
+// When delegation is enabled and the request is authenticated, the client acts on behalf of the
+// caller; otherwise the pooled client authenticated with the profile's M2M credentials is returned.
+//
+// Pooled clients are created lazily, without holding clientMu, and concurrent requests for the same
+// profile wait for the initialization in progress. A failed initialization is not permanent: it is
+// retried on a later call once initRetryInterval has passed, or immediately after Reload.
 func GetClientInstance(ctx context.Context) (*sdk.Client, error) {
-	once.Do(func() {
-		baseURL, clientID, clientSecret, certPath, err := internal_config.Load()
-		if err != nil {
-			initErr = err
-			return
+	profile, err := ResolveProfile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if DelegationEnabled() {
+		if principal := auth.PrincipalFromContext(ctx); principal != nil {
+			return getDelegatedClient(ctx, profile, principal.Token)
 		}
+	}
 
-		clientInstance, initErr = NewClient(ctx, baseURL, clientID, clientSecret, certPath)
-	})
+	clientMu.Lock()
+	entry, ok := pool[profile.Name]
+	switch {
+	case ok && entry.client != nil:
+		clientMu.Unlock()
+		return entry.client, nil
+	case ok && entry.init != nil:
+		clientMu.Unlock()
+		return entry.init.wait(ctx)
+	case ok && time.Since(entry.triedAt) < initRetryInterval:
+		clientMu.Unlock()
+		return nil, entry.err
+	}
+	initializing := newFlight[*sdk.Client]()
+	pool[profile.Name] = &poolEntry{init: initializing}
+	clientMu.Unlock()
+
+	// The initialization is shared with the requests waiting for it, so it does not end with this request.
+	client, err := initClient(context.WithoutCancel(ctx), profile)
+
+	clientMu.Lock()
+	// Reload may have replaced the pool in the meantime; its settings take precedence.
+	if current, ok := pool[profile.Name]; ok && current.init == initializing {
+		pool[profile.Name] = &poolEntry{client: client, err: err, triedAt: time.Now()}
+	}
+	clientMu.Unlock()
+	initializing.finish(client, err)
+	return client, err
+}
+
+// ResolveProfile returns the profile selected for the request.
+func ResolveProfile(ctx context.Context) (internal_config.Profile, error) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if err := loadProfiles(); err != nil {
+		return internal_config.Profile{}, err
+	}
+
+	name, _ := ctx.Value(profileKey{}).(string)
+	if name == "" {
+		name = sessionProfiles[sessionID(ctx)]
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return internal_config.Profile{}, fmt.Errorf("%w %q; available profiles: %s", ErrUnknownProfile,
+			name, strings.Join(internal_config.ProfileNames(profiles), ", "))
+	}
+	return profile, nil
+}
+
+// Profiles returns the configured profiles, the default profile and the profile selected for the session.
+func Profiles(ctx context.Context) (map[string]internal_config.Profile, string, string, error) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if err := loadProfiles(); err != nil {
+		return nil, "", "", err
+	}
+	current := sessionProfiles[sessionID(ctx)]
+	if current == "" {
+		current = defaultProfile
+	}
+	return profiles, defaultProfile, current, nil
+}
+
+// SetSessionProfile selects the profile used by the calling session when a tool call does not name one.
+func SetSessionProfile(ctx context.Context, name string) error {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if err := loadProfiles(); err != nil {
+		return err
+	}
+	name = strings.ToLower(name)
+	if _, ok := profiles[name]; !ok {
+		return fmt.Errorf("%w %q; available profiles: %s", ErrUnknownProfile,
+			name, strings.Join(internal_config.ProfileNames(profiles), ", "))
+	}
+	sessionProfiles[sessionID(ctx)] = name
+	return nil
+}
+
+// SessionProfile returns the name of the profile used by the session when a request does not name one.
+func SessionProfile(sessionID string) (string, error) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if err := loadProfiles(); err != nil {
+		return "", err
+	}
+	if name := sessionProfiles[sessionID]; name != "" {
+		return name, nil
+	}
+	return defaultProfile, nil
+}
+
+// ForgetSession drops the profile selection of a session that has ended.
+func ForgetSession(_ context.Context, session server.ClientSession) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	delete(sessionProfiles, session.SessionID())
+}
+
+// Reload discards the current clients, profiles and discovered endpoints and initializes the client of the selected
+// profile again from the current configuration, so that connection settings can be changed
+// without a restart.
+func Reload(ctx context.Context) (*sdk.Client, error) {
+	ForgetOIDCEndpoints()
+
+	delegatedMu.Lock()
+	delegatedClients = map[string]delegatedClient{}
+	delegatedMu.Unlock()
+
+	clientMu.Lock()
+	profiles = nil
+	pool = map[string]*poolEntry{}
+	clientMu.Unlock()
+
+	client, err := GetClientInstance(ctx)
+	if err != nil {
+		log.Printf("Error reloading client instance: %v", err)
+	} else {
+		log.Printf("Client instance reloaded for %s", client.Config.BaseURL)
+	}
+	return client, err
+}
+
+// loadProfiles reads the profiles from the configuration if they are not loaded yet.
+// It must be called with clientMu held.
+func loadProfiles() error {
+	if profiles != nil {
+		return nil
+	}
+	loaded, defaultName, err := internal_config.LoadProfiles()
+	if err != nil {
+		return fmt.Errorf("%s connection is %w: %v", internal_config.GetProductName(), ErrNotConfigured, err)
+	}
+	profiles, defaultProfile = loaded, defaultName
+	return nil
+}
+
+// initClient creates the client of the profile and verifies it by obtaining an access token.
+func initClient(ctx context.Context, profile internal_config.Profile) (*sdk.Client, error) {
+	productName := profile.ProductName()
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("%s connection is %w: %v", productName, ErrNotConfigured, err)
+	}
+
+	client, err := NewClient(ctx, profile.BaseURL, profile.ClientID, profile.ClientSecret, profile.CertPath, profile.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("%s connection is %w: %v", productName, ErrNotConfigured, err)
+	}
+
+	if _, err := client.Config.GetToken(ctx); err != nil {
+		return nil, fmt.Errorf("%s at %s (profile %q) is %w or rejected the client credentials: %v",
+			productName, profile.BaseURL, profile.Name, ErrUnreachable, err)
+	}
+	return client, nil
+}
 
-	return clientInstance, initErr
+func sessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
 }
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asgardeo/go/pkg/config"
+	"github.com/asgardeo/go/pkg/sdk"
+	internal_config "github.com/asgardeo/mcp/internal/config"
+)
+
+// expiryBuffer is subtracted from the lifetime of an exchanged token so that it
+// is renewed before it expires in the middle of a tool call.
+const expiryBuffer = 30 * time.Second
+
+type delegatedClient struct {
+	client    *sdk.Client
+	expiresAt time.Time
+}
+
+var (
+	delegationConfig *internal_config.DelegationConfig
+	delegatedClients = map[string]delegatedClient{}
+	exchanges        = map[string]*flight[delegatedClient]{}
+	delegatedMu      sync.Mutex
+)
+
+// EnableDelegation makes GetClientInstance return a client acting on behalf of the
+// authenticated caller, using a token obtained through RFC 8693 token exchange.
+func EnableDelegation(cfg internal_config.DelegationConfig) {
+	delegatedMu.Lock()
+	defer delegatedMu.Unlock()
+	delegationConfig = &cfg
+}
+
+// DelegationEnabled reports whether the clients act on behalf of the authenticated caller.
+func DelegationEnabled() bool {
+	delegatedMu.Lock()
+	defer delegatedMu.Unlock()
+	return delegationConfig != nil && delegationConfig.Enabled
+}
+
+// NewClientWithToken initializes an Asgardeo management client that authenticates with the given access token.
+func NewClientWithToken(baseURL, token string, certPath *string, timeout time.Duration) (*sdk.Client, error) {
+	cfg := config.DefaultClientConfig().
+		WithBaseURL(baseURL).
+		WithTimeout(timeout).
+		WithToken(token).
+		WithCertificatePath(certPath)
+
+	return sdk.New(cfg)
+}
+
+// getDelegatedClient returns a cached client for the subject token and profile, exchanging the token when needed.
+// The exchange runs outside delegatedMu; concurrent requests with the same token wait for it.
+func getDelegatedClient(ctx context.Context, profile internal_config.Profile, subjectToken string) (*sdk.Client, error) {
+	key := profile.Name + ":" + tokenKey(subjectToken)
+
+	delegatedMu.Lock()
+	if cached, ok := delegatedClients[key]; ok && time.Now().Before(cached.expiresAt) {
+		delegatedMu.Unlock()
+		return cached.client, nil
+	}
+	if exchange, ok := exchanges[key]; ok {
+		delegatedMu.Unlock()
+		delegated, err := exchange.wait(ctx)
+		return delegated.client, err
+	}
+	exchange := newFlight[delegatedClient]()
+	exchanges[key] = exchange
+	delegatedMu.Unlock()
+
+	// The exchange is shared with the requests waiting for it, so it does not end with this request.
+	delegated, err := newDelegatedClient(context.WithoutCancel(ctx), profile, subjectToken)
+
+	delegatedMu.Lock()
+	delete(exchanges, key)
+	if err == nil {
+		now := time.Now()
+		for k, cached := range delegatedClients {
+			if now.After(cached.expiresAt) {
+				delete(delegatedClients, k)
+			}
+		}
+		delegatedClients[key] = delegated
+	}
+	delegatedMu.Unlock()
+	exchange.finish(delegated, err)
+	return delegated.client, err
+}
+
+// newDelegatedClient exchanges the subject token and creates a client that authenticates with the exchanged token.
+func newDelegatedClient(ctx context.Context, profile internal_config.Profile, subjectToken string) (delegatedClient, error) {
+	if err := profile.Validate(); err != nil {
+		return delegatedClient{}, fmt.Errorf("%s connection is %w: %v", profile.ProductName(), ErrNotConfigured, err)
+	}
+	requestedAt := time.Now()
+	token, expiresIn, err := exchangeToken(ctx, profile, subjectToken)
+	if err != nil {
+		return delegatedClient{}, err
+	}
+	client, err := NewClientWithToken(profile.BaseURL, token, profile.CertPath, profile.Timeout)
+	if err != nil {
+		return delegatedClient{}, err
+	}
+	return delegatedClient{
+		client:    client,
+		expiresAt: requestedAt.Add(time.Duration(expiresIn)*time.Second - expiryBuffer),
+	}, nil
+}
+
+// exchangeToken exchanges the caller's token for a management API token issued to the caller.
+func exchangeToken(ctx context.Context, profile internal_config.Profile, subjectToken string) (string, int, error) {
+	data := url.Values{}
+	data.Set("grant_type", internal_config.TOKEN_EXCHANGE_GRANT_TYPE)
+	data.Set("subject_token", subjectToken)
+	data.Set("subject_token_type", delegationConfig.SubjectTokenType)
+	data.Set("requested_token_type", internal_config.TOKEN_TYPE_ACCESS_TOKEN)
+	data.Set("scope", strings.Join(delegationConfig.Scopes, " "))
+
+	httpClient := config.DefaultClientConfig().WithTimeout(profile.Timeout).WithCertificatePath(profile.CertPath).HTTPClient
+	productMode := internal_config.ProductModes.Asgardeo
+	if profile.ProductMode == internal_config.ProductModes.WSO2IS {
+		productMode = internal_config.ProductModes.WSO2IS
+	}
+	endpoints := resolveEndpoints(ctx, httpClient, strings.TrimSuffix(profile.BaseURL, "/"), productMode)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoints.Token, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(profile.ClientID, profile.ClientSecret)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to exchange token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&oauthErr)
+		return "", 0, fmt.Errorf("token exchange failed: HTTP %d %s %s", resp.StatusCode, oauthErr.Error, oauthErr.ErrorDescription)
+	}
+
+	var tokenResp config.TokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", 0, fmt.Errorf("failed to parse token exchange response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return "", 0, fmt.Errorf("received empty access token from token exchange")
+	}
+	return tokenResp.AccessToken, tokenResp.ExpiresIn, nil
+}
+
+// tokenKey avoids keeping raw caller tokens as map keys.
+func tokenKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asgardeo/go/pkg/sdk"
+	internal_config "github.com/asgardeo/mcp/internal/config"
+)
+
+// Sources of the reported endpoints.
+const (
+	EndpointSourceDiscovery = "discovery"
+	EndpointSourceStatic    = "static"
+)
+
+// OIDCEndpoints are the OAuth 2.0 and OpenID Connect endpoints that applications of an
+// organization use. Endpoints that the server does not advertise are left empty.
+type OIDCEndpoints struct {
+	BaseURL             string `json:"base_url"`
+	Issuer              string `json:"issuer"`
+	Authorize           string `json:"authorize_url"`
+	Token               string `json:"token_url"`
+	JWKS                string `json:"jwks_url"`
+	UserInfo            string `json:"userinfo_url,omitempty"`
+	Logout              string `json:"logout_url,omitempty"`
+	Introspection       string `json:"introspection_url,omitempty"`
+	Revocation          string `json:"revocation_url,omitempty"`
+	PushedAuthorization string `json:"par_url,omitempty"`
+	DeviceAuthorization string `json:"device_authorization_url,omitempty"`
+	Discovery           string `json:"discovery_url,omitempty"`
+	// Source tells whether the endpoints were read from the discovery document or derived from
+	// the base URL because discovery failed.
+	Source string `json:"source"`
+}
+
+// discoveryDocument holds the fields of /.well-known/openid-configuration that are reported.
+type discoveryDocument struct {
+	Issuer                             string `json:"issuer"`
+	AuthorizationEndpoint              string `json:"authorization_endpoint"`
+	TokenEndpoint                      string `json:"token_endpoint"`
+	JWKSURI                            string `json:"jwks_uri"`
+	UserInfoEndpoint                   string `json:"userinfo_endpoint"`
+	EndSessionEndpoint                 string `json:"end_session_endpoint"`
+	IntrospectionEndpoint              string `json:"introspection_endpoint"`
+	RevocationEndpoint                 string `json:"revocation_endpoint"`
+	PushedAuthorizationRequestEndpoint string `json:"pushed_authorization_request_endpoint"`
+	DeviceAuthorizationEndpoint        string `json:"device_authorization_endpoint"`
+}
+
+// discoveryPaths are the locations of the discovery document relative to the base URL, tried in
+// order. Older WSO2 Identity Server versions only serve it under /oauth2/oidcdiscovery.
+var discoveryPaths = map[string][]string{
+	internal_config.ProductModes.Asgardeo: {"/oauth2/token/.well-known/openid-configuration"},
+	internal_config.ProductModes.WSO2IS: {
+		"/oauth2/token/.well-known/openid-configuration",
+		"/oauth2/oidcdiscovery/.well-known/openid-configuration",
+	},
+}
+
+type cachedEndpoints struct {
+	endpoints OIDCEndpoints
+	expiresAt time.Time
+}
+
+var (
+	endpointsMu    sync.Mutex
+	endpointsCache = map[string]cachedEndpoints{}
+)
+
+// ResolveOIDCEndpoints returns the endpoints of the organization the client connects to, read from
+// its OpenID Connect discovery document. The result is cached per base URL. When discovery fails,
+// the endpoints are derived from the base URL with the default paths of the product, and discovery
+// is retried after OIDC_DISCOVERY_RETRY_INTERVAL.
+func ResolveOIDCEndpoints(ctx context.Context, client *sdk.Client) OIDCEndpoints {
+	baseURL := strings.TrimSuffix(client.Config.BaseURL, "/")
+	productMode := internal_config.ProductModes.Asgardeo
+	if profile, err := ResolveProfile(ctx); err == nil && profile.ProductMode == internal_config.ProductModes.WSO2IS {
+		productMode = internal_config.ProductModes.WSO2IS
+	}
+	return resolveEndpoints(ctx, client.Config.HTTPClient, baseURL, productMode)
+}
+
+// resolveEndpoints returns the endpoints of the organization at the base URL, discovered with the
+// HTTP client and cached like ResolveOIDCEndpoints.
+func resolveEndpoints(ctx context.Context, httpClient *http.Client, baseURL, productMode string) OIDCEndpoints {
+	cacheKey := productMode + " " + baseURL
+
+	endpointsMu.Lock()
+	cached, ok := endpointsCache[cacheKey]
+	endpointsMu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.endpoints
+	}
+
+	endpoints, err := discoverEndpoints(ctx, httpClient, baseURL, productMode)
+	ttl := internal_config.OIDC_DISCOVERY_CACHE_TTL
+	if err != nil {
+		log.Printf("OIDC discovery failed for %s, using the default endpoints: %v", baseURL, err)
+		endpoints = staticEndpoints(baseURL)
+		ttl = internal_config.OIDC_DISCOVERY_RETRY_INTERVAL
+	}
+
+	endpointsMu.Lock()
+	endpointsCache[cacheKey] = cachedEndpoints{endpoints: endpoints, expiresAt: time.Now().Add(ttl)}
+	endpointsMu.Unlock()
+	return endpoints
+}
+
+// ForgetOIDCEndpoints drops the cached endpoints, so that they are discovered again.
+func ForgetOIDCEndpoints() {
+	endpointsMu.Lock()
+	defer endpointsMu.Unlock()
+	endpointsCache = map[string]cachedEndpoints{}
+}
+
+func discoverEndpoints(ctx context.Context, httpClient *http.Client, baseURL, productMode string) (OIDCEndpoints, error) {
+	var errs []string
+	for _, path := range discoveryPaths[productMode] {
+		discoveryURL := baseURL + path
+		doc, err := fetchDiscoveryDocument(ctx, httpClient, discoveryURL)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		return OIDCEndpoints{
+			BaseURL:             baseURL,
+			Issuer:              doc.Issuer,
+			Authorize:           doc.AuthorizationEndpoint,
+			Token:               doc.TokenEndpoint,
+			JWKS:                doc.JWKSURI,
+			UserInfo:            doc.UserInfoEndpoint,
+			Logout:              doc.EndSessionEndpoint,
+			Introspection:       doc.IntrospectionEndpoint,
+			Revocation:          doc.RevocationEndpoint,
+			PushedAuthorization: doc.PushedAuthorizationRequestEndpoint,
+			DeviceAuthorization: doc.DeviceAuthorizationEndpoint,
+			Discovery:           discoveryURL,
+			Source:              EndpointSourceDiscovery,
+		}, nil
+	}
+	return OIDCEndpoints{}, fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+func fetchDiscoveryDocument(ctx context.Context, httpClient *http.Client, discoveryURL string) (*discoveryDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", discoveryURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s failed: status %d", discoveryURL, resp.StatusCode)
+	}
+
+	doc := &discoveryDocument{}
+	if err := json.NewDecoder(resp.Body).Decode(doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", discoveryURL, err)
+	}
+	if doc.Issuer == "" || doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" {
+		return nil, fmt.Errorf("%s lacks the issuer, authorization or token endpoint", discoveryURL)
+	}
+	return doc, nil
+}
+
+// staticEndpoints derives the endpoints from the base URL with the default paths, which Asgardeo
+// and WSO2 Identity Server share. Custom domains are not known without discovery.
+func staticEndpoints(baseURL string) OIDCEndpoints {
+	return OIDCEndpoints{
+		BaseURL:             baseURL,
+		Issuer:              baseURL + "/oauth2/token",
+		Authorize:           baseURL + "/oauth2/authorize",
+		Token:               baseURL + "/oauth2/token",
+		JWKS:                baseURL + "/oauth2/jwks",
+		UserInfo:            baseURL + "/oauth2/userinfo",
+		Logout:              baseURL + "/oidc/logout",
+		Introspection:       baseURL + "/oauth2/introspect",
+		Revocation:          baseURL + "/oauth2/revoke",
+		PushedAuthorization: baseURL + "/oauth2/par",
+		DeviceAuthorization: baseURL + "/oauth2/device_authorize",
+		Source:              EndpointSourceStatic,
+	}
+}
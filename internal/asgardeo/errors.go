@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrorCategory tells what kind of failure an error is, so that it can be recovered from.
+type ErrorCategory string
+
+const (
+	CategoryAuth        ErrorCategory = "auth"
+	CategoryPermission  ErrorCategory = "permission"
+	CategoryNotFound    ErrorCategory = "not_found"
+	CategoryConflict    ErrorCategory = "conflict"
+	CategoryValidation  ErrorCategory = "validation"
+	CategoryRateLimited ErrorCategory = "rate_limited"
+	CategoryUpstream    ErrorCategory = "upstream"
+)
+
+// remediations holds the default recovery hint of each category.
+var remediations = map[ErrorCategory]string{
+	CategoryAuth: "The management API credentials are missing, invalid or expired. Check the client ID and " +
+		"secret of the profile, then call reload_configuration.",
+	CategoryPermission: "The M2M application of the profile is not allowed to perform this operation. Authorize " +
+		"the management API that covers it, with the required scopes, to the M2M application.",
+	CategoryNotFound: "The resource does not exist. Use a list or search tool to find the correct ID or name " +
+		"before retrying.",
+	CategoryConflict: "A resource with the same name or identifier already exists. Use the existing resource " +
+		"or retry with a different name.",
+	CategoryValidation: "The request was rejected as invalid. Correct the arguments mentioned in the description " +
+		"and retry.",
+	CategoryRateLimited: "Too many requests were sent. Wait before retrying and reduce the number of calls, " +
+		"for example by using larger pages.",
+	CategoryUpstream: "The server failed to process the request or could not be reached. Retry later; if the " +
+		"problem persists, report the trace ID to the administrator.",
+}
+
+// ErrorDetails describes a failed call to the management APIs.
+type ErrorDetails struct {
+	Category    ErrorCategory `json:"category"`
+	Message     string        `json:"message"`
+	StatusCode  int           `json:"status_code,omitempty"`
+	Code        string        `json:"code,omitempty"`
+	Description string        `json:"description,omitempty"`
+	TraceID     string        `json:"trace_id,omitempty"`
+	Remediation string        `json:"remediation"`
+}
+
+// upstreamError is the error body returned by the management APIs.
+type upstreamError struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+	TraceID     string `json:"traceId"`
+}
+
+// statusPatterns extract the HTTP status from the error messages of the SDK, which embed it as
+// "status 404", "HTTP 401" or a response status line such as "409 Conflict".
+var statusPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bstatus (\d{3})\b`),
+	regexp.MustCompile(`\bHTTP (\d{3})\b`),
+	regexp.MustCompile(`: (\d{3}) [A-Z][a-z]`),
+}
+
+// ClassifyError describes an error returned by the SDK or by CallManagementAPI. The SDK only
+// reports failures as text, so the status and the error body are recovered from the message.
+func ClassifyError(err error) ErrorDetails {
+	details := ErrorDetails{Message: err.Error()}
+
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr):
+		details.StatusCode = apiErr.StatusCode
+		parseUpstreamError(apiErr.Body, &details)
+	default:
+		details.StatusCode = parseStatusCode(details.Message)
+		parseUpstreamError(details.Message, &details)
+	}
+
+	details.Category = categorize(err, details)
+	details.Remediation = remediations[details.Category]
+	if errors.Is(err, ErrUnknownProfile) {
+		details.Remediation = "Use list_profiles to see the configured profiles and retry with one of them."
+	}
+	return details
+}
+
+func categorize(err error, details ErrorDetails) ErrorCategory {
+	switch {
+	case errors.Is(err, ErrNotConfigured):
+		return CategoryAuth
+	case errors.Is(err, ErrUnknownProfile):
+		return CategoryValidation
+	case errors.Is(err, ErrUnreachable):
+		if details.StatusCode == http.StatusBadRequest || details.StatusCode == http.StatusUnauthorized {
+			return CategoryAuth
+		}
+		return CategoryUpstream
+	}
+
+	switch status := details.StatusCode; {
+	case status == http.StatusUnauthorized:
+		return CategoryAuth
+	case status == http.StatusForbidden:
+		return CategoryPermission
+	case status == http.StatusNotFound:
+		return CategoryNotFound
+	case status == http.StatusConflict:
+		return CategoryConflict
+	case status == http.StatusTooManyRequests:
+		return CategoryRateLimited
+	case status >= 400 && status < 500:
+		return CategoryValidation
+	case status >= 500:
+		return CategoryUpstream
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return CategoryUpstream
+	}
+	// Lookups by name or identifier report a missing resource without a status.
+	if strings.Contains(strings.ToLower(details.Message), "not found") {
+		return CategoryNotFound
+	}
+	return CategoryUpstream
+}
+
+func parseStatusCode(message string) int {
+	for _, pattern := range statusPatterns {
+		if match := pattern.FindStringSubmatch(message); match != nil {
+			status, _ := strconv.Atoi(match[1])
+			return status
+		}
+	}
+	return 0
+}
+
+// parseUpstreamError copies the code, description and trace ID of the first JSON error body found in text.
+func parseUpstreamError(text string, details *ErrorDetails) {
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return
+	}
+	var body upstreamError
+	if err := json.NewDecoder(strings.NewReader(text[start:])).Decode(&body); err != nil {
+		return
+	}
+	details.Code = body.Code
+	details.Description = body.Description
+	if details.Description == "" {
+		details.Description = body.Message
+	}
+	details.TraceID = body.TraceID
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import "context"
+
+// flight is a call in progress whose result concurrent callers wait for instead of making the
+// same call, so that it can run without holding the lock that guards its cache.
+type flight[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
+}
+
+func newFlight[T any]() *flight[T] {
+	return &flight[T]{done: make(chan struct{})}
+}
+
+// wait returns the result of the call once it is done, or the error of the context if the caller
+// gives up first.
+func (f *flight[T]) wait(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// finish records the result of the call and releases the callers waiting for it.
+func (f *flight[T]) finish(value T, err error) {
+	f.value, f.err = value, err
+	close(f.done)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/asgardeo/go/pkg/sdk"
+)
+
+// managementAPIPath is the base path of the server management REST APIs.
+const managementAPIPath = "/api/server/v1"
+
+// APIError is returned when a management API responds with an unexpected status.
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s failed: status %d, body: %s", e.Method, e.Path, e.StatusCode, e.Body)
+}
+
+// CallManagementAPI calls a management API that the SDK does not cover, authenticating with the
+// client's credentials. The path is relative to /api/server/v1. A non-nil body is sent as JSON and
+// a successful JSON response is decoded into out when it is not nil.
+func CallManagementAPI(ctx context.Context, client *sdk.Client, method, path string, body, out any) error {
+	_, err := callManagementAPI(ctx, client, method, path, body, out)
+	return err
+}
+
+// callManagementAPI is CallManagementAPI that also returns the response headers, e.g. the Location
+// of a created resource.
+func callManagementAPI(ctx context.Context, client *sdk.Client, method, path string, body, out any) (http.Header, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	url := strings.TrimSuffix(client.Config.BaseURL, "/") + managementAPIPath + path
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	token, err := client.Config.GetToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authentication token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &APIError{Method: method, Path: path, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return nil, fmt.Errorf("failed to parse response body: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
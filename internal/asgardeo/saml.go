@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package asgardeo
+
+import (
+	"context"
+	"strings"
+
+	"github.com/asgardeo/go/pkg/sdk"
+	"github.com/asgardeo/mcp/internal/saml"
+)
+
+// samlIdPMetadataPath is the path of the SAML 2.0 identity provider metadata of the tenant.
+const samlIdPMetadataPath = "/identity/metadata/saml2"
+
+// SAMLIdPMetadataURL returns the URL of the SAML 2.0 identity provider metadata of the tenant.
+func SAMLIdPMetadataURL(client *sdk.Client) string {
+	return strings.TrimSuffix(client.Config.BaseURL, "/") + samlIdPMetadataPath
+}
+
+// GetSAMLIdPMetadata downloads the SAML 2.0 identity provider metadata of the tenant, which service
+// providers need to trust its assertions.
+func GetSAMLIdPMetadata(ctx context.Context, client *sdk.Client) ([]byte, error) {
+	return saml.Fetch(ctx, client.Config.HTTPClient, SAMLIdPMetadataURL(client))
+}
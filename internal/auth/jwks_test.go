@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestKeySetRefreshesForUnknownKeyID(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	validator := newTestValidator(t, issuer.config())
+	ctx := context.Background()
+
+	if _, err := validator.Validate(ctx, issuer.sign("key-1", issuer.claims())); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := issuer.fetchCount(); got != 1 {
+		t.Fatalf("JWKS fetched %d times, want 1", got)
+	}
+
+	// The issuer rotates to a new key right after the JWKS was fetched.
+	issuer.addKey("key-2")
+	rotated := issuer.sign("key-2", issuer.claims())
+	if _, err := validator.Validate(ctx, rotated); err == nil {
+		t.Fatal("Validate() accepted a key that is not refetched yet")
+	}
+	if got := issuer.fetchCount(); got != 1 {
+		t.Fatalf("JWKS fetched %d times within the minimum refresh interval, want 1", got)
+	}
+
+	validator.keys.fetchedAt = time.Now().Add(-minRefreshInterval - time.Second)
+	if _, err := validator.Validate(ctx, rotated); err != nil {
+		t.Fatalf("Validate() error = %v after the key set was refreshed", err)
+	}
+	if got := issuer.fetchCount(); got != 2 {
+		t.Errorf("JWKS fetched %d times, want 2", got)
+	}
+	if _, err := validator.Validate(ctx, issuer.sign("key-1", issuer.claims())); err != nil {
+		t.Errorf("Validate() error = %v for the previous key", err)
+	}
+	if got := issuer.fetchCount(); got != 2 {
+		t.Errorf("JWKS fetched %d times for a cached key, want 2", got)
+	}
+}
+
+func TestKeySetRefreshesExpiredCache(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	keys := NewKeySet(issuer.config().JWKSURL, time.Minute, http.DefaultClient)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := keys.Key(ctx, "key-1"); err != nil {
+			t.Fatalf("Key() error = %v", err)
+		}
+	}
+	if got := issuer.fetchCount(); got != 1 {
+		t.Fatalf("JWKS fetched %d times, want 1", got)
+	}
+
+	keys.fetchedAt = time.Now().Add(-2 * time.Minute)
+	if _, err := keys.Key(ctx, "key-1"); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+	if got := issuer.fetchCount(); got != 2 {
+		t.Errorf("JWKS fetched %d times after the cache expired, want 2", got)
+	}
+}
+
+func TestKeySetWithoutKeyID(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	keys := NewKeySet(issuer.config().JWKSURL, time.Hour, http.DefaultClient)
+	if _, err := keys.Key(context.Background(), ""); err != nil {
+		t.Errorf("Key() error = %v for a set with a single key", err)
+	}
+
+	issuer.addKey("key-2")
+	keys = NewKeySet(issuer.config().JWKSURL, time.Hour, http.DefaultClient)
+	if _, err := keys.Key(context.Background(), ""); err == nil {
+		t.Error("Key() chose a key without a kid from a set with several keys")
+	}
+}
+
+func TestKeySetFetchFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	keys := NewKeySet(server.URL, time.Hour, http.DefaultClient)
+	if _, err := keys.Key(context.Background(), "key-1"); err == nil {
+		t.Error("Key() succeeded although the JWKS could not be fetched")
+	}
+}
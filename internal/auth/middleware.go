@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/asgardeo/mcp/internal/config"
+)
+
+// ProtectedResourceMetadataPath is the well-known path of the OAuth 2.0
+// protected resource metadata document (RFC 9728).
+const ProtectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+// ProtectedResource guards the MCP endpoint as an OAuth 2.1 protected resource.
+type ProtectedResource struct {
+	cfg       config.AuthConfig
+	basePath  string
+	validator *Validator
+}
+
+// NewProtectedResource creates a protected resource for the MCP endpoint served at basePath.
+func NewProtectedResource(cfg config.AuthConfig, basePath string) (*ProtectedResource, error) {
+	validator, err := NewValidator(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ProtectedResource{
+		cfg:       cfg,
+		basePath:  basePath,
+		validator: validator,
+	}, nil
+}
+
+// MetadataPaths returns the paths the metadata document is served at: the
+// root well-known path and the path-suffixed variant for the MCP endpoint.
+func (p *ProtectedResource) MetadataPaths() []string {
+	return []string{ProtectedResourceMetadataPath, ProtectedResourceMetadataPath + p.basePath}
+}
+
+// MetadataHandler serves the protected resource metadata document.
+func (p *ProtectedResource) MetadataHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		metadata := map[string]interface{}{
+			"resource":                 p.resourceURL(r),
+			"authorization_servers":    p.cfg.AuthorizationServers,
+			"bearer_methods_supported": []string{"header"},
+			"resource_name":            "Asgardeo Management MCP",
+		}
+		if len(p.cfg.RequiredScopes) > 0 {
+			metadata["scopes_supported"] = p.cfg.RequiredScopes
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(metadata); err != nil {
+			log.Printf("Error writing protected resource metadata: %v", err)
+		}
+	})
+}
+
+// Middleware rejects requests that do not carry a valid bearer token and
+// attaches the authenticated caller to the request context.
+func (p *ProtectedResource) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			p.challenge(w, r, http.StatusUnauthorized, "", "")
+			return
+		}
+
+		principal, err := p.validator.Validate(r.Context(), strings.TrimSpace(token))
+		if err != nil {
+			log.Printf("Rejected bearer token: %v", err)
+			p.challenge(w, r, http.StatusUnauthorized, "invalid_token", "The access token is invalid or expired")
+			return
+		}
+		for _, scope := range p.cfg.RequiredScopes {
+			if !slices.Contains(principal.Scopes, scope) {
+				p.challenge(w, r, http.StatusForbidden, "insufficient_scope", "The access token does not grant the required scopes")
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
+
+func (p *ProtectedResource) challenge(w http.ResponseWriter, r *http.Request, status int, errorCode, description string) {
+	params := []string{fmt.Sprintf("resource_metadata=%q", p.metadataURL(r))}
+	if errorCode != "" {
+		params = append(params, fmt.Sprintf("error=%q", errorCode), fmt.Sprintf("error_description=%q", description))
+	}
+	if errorCode == "insufficient_scope" {
+		params = append(params, fmt.Sprintf("scope=%q", strings.Join(p.cfg.RequiredScopes, " ")))
+	}
+	w.Header().Set("WWW-Authenticate", "Bearer "+strings.Join(params, ", "))
+	http.Error(w, http.StatusText(status), status)
+}
+
+func (p *ProtectedResource) resourceURL(r *http.Request) string {
+	if p.cfg.ResourceURL != "" {
+		return p.cfg.ResourceURL
+	}
+	return origin(r) + p.basePath
+}
+
+func (p *ProtectedResource) metadataURL(r *http.Request) string {
+	return origin(r) + ProtectedResourceMetadataPath + p.basePath
+}
+
+// origin reconstructs the externally visible scheme and host of the request,
+// honouring the headers set by a TLS terminating proxy.
+func origin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+	host := r.Host
+	if forwarded := r.Header.Get("X-Forwarded-Host"); forwarded != "" {
+		host = forwarded
+	}
+	return scheme + "://" + host
+}
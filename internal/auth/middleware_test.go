@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/asgardeo/mcp/internal/config"
+)
+
+const testMetadataURL = "http://mcp.example.com/.well-known/oauth-protected-resource/mcp"
+
+// serveProtected sends a request with the Authorization header, if any, to a handler behind the middleware
+// and reports the caller the handler saw.
+func serveProtected(t *testing.T, resource *ProtectedResource, authorization string) (*httptest.ResponseRecorder, *Principal) {
+	t.Helper()
+	var principal *Principal
+	handler := resource.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "http://mcp.example.com/mcp", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec, principal
+}
+
+func newTestProtectedResource(t *testing.T, issuer *testIssuer, requiredScopes ...string) *ProtectedResource {
+	t.Helper()
+	t.Setenv(config.CERTIFICATE_PATH_PARAM, "")
+	cfg := issuer.config()
+	cfg.RequiredScopes = requiredScopes
+	resource, err := NewProtectedResource(cfg, "/mcp")
+	if err != nil {
+		t.Fatalf("NewProtectedResource() error = %v", err)
+	}
+	return resource
+}
+
+func TestMiddlewareAcceptsValidToken(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	resource := newTestProtectedResource(t, issuer, "internal_application_mgt_view")
+
+	rec, principal := serveProtected(t, resource, "Bearer "+issuer.sign("key-1", issuer.claims()))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if principal == nil || principal.Subject != "alice" {
+		t.Errorf("principal = %+v, want the subject alice", principal)
+	}
+}
+
+func TestMiddlewareRejectsMissingOrInvalidToken(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	resource := newTestProtectedResource(t, issuer)
+	expired := issuer.claims()
+	expired["exp"] = 1
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantChallenge string
+	}{
+		{
+			name:          "missing header",
+			wantChallenge: `Bearer resource_metadata="` + testMetadataURL + `"`,
+		},
+		{
+			name:          "other scheme",
+			authorization: "Basic YWxpY2U6c2VjcmV0",
+			wantChallenge: `Bearer resource_metadata="` + testMetadataURL + `"`,
+		},
+		{
+			name:          "empty token",
+			authorization: "Bearer  ",
+			wantChallenge: `Bearer resource_metadata="` + testMetadataURL + `"`,
+		},
+		{
+			name:          "malformed token",
+			authorization: "Bearer not-a-jwt",
+			wantChallenge: `Bearer resource_metadata="` + testMetadataURL + `", error="invalid_token", error_description="The access token is invalid or expired"`,
+		},
+		{
+			name:          "expired token",
+			authorization: "Bearer " + issuer.sign("key-1", expired),
+			wantChallenge: `Bearer resource_metadata="` + testMetadataURL + `", error="invalid_token", error_description="The access token is invalid or expired"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, principal := serveProtected(t, resource, tt.authorization)
+			if rec.Code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+			if got := rec.Header().Get("WWW-Authenticate"); got != tt.wantChallenge {
+				t.Errorf("WWW-Authenticate = %q, want %q", got, tt.wantChallenge)
+			}
+			if principal != nil {
+				t.Error("the protected handler was called")
+			}
+		})
+	}
+}
+
+func TestMiddlewareRejectsInsufficientScope(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	resource := newTestProtectedResource(t, issuer, "internal_user_mgt_create")
+
+	rec, principal := serveProtected(t, resource, "Bearer "+issuer.sign("key-1", issuer.claims()))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	challenge := rec.Header().Get("WWW-Authenticate")
+	if !strings.Contains(challenge, `error="insufficient_scope"`) || !strings.Contains(challenge, `scope="internal_user_mgt_create"`) {
+		t.Errorf("WWW-Authenticate = %q, want an insufficient_scope challenge naming the scope", challenge)
+	}
+	if principal != nil {
+		t.Error("the protected handler was called")
+	}
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// clockSkew is the leeway allowed when checking the exp and nbf claims.
+const clockSkew = 30 * time.Second
+
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Principal describes the caller authenticated by a bearer token.
+type Principal struct {
+	Subject string
+	Token   string
+	Scopes  []string
+	Claims  jwt.MapClaims
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of the context carrying the authenticated caller.
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the authenticated caller, or nil when the request was not authenticated.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	if principal, ok := ctx.Value(principalKey{}).(*Principal); ok {
+		return principal
+	}
+	return nil
+}
+
+// Validator verifies JWT access tokens against the configured issuer, audience and JWKS.
+type Validator struct {
+	cfg    config.AuthConfig
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+// NewValidator creates a token validator for the given settings.
+func NewValidator(cfg config.AuthConfig) (*Validator, error) {
+	httpClient, err := newHTTPClient(os.Getenv(config.CERTIFICATE_PATH_PARAM))
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{
+		cfg:  cfg,
+		keys: NewKeySet(cfg.JWKSURL, cfg.JWKSCacheTTL, httpClient),
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(signingMethods),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(clockSkew),
+		),
+	}, nil
+}
+
+// Validate parses the token, verifies its signature and standard claims and returns the caller.
+func (v *Validator) Validate(ctx context.Context, token string) (*Principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subject, _ := claims.GetSubject()
+	return &Principal{
+		Subject: subject,
+		Token:   token,
+		Scopes:  scopesOf(claims),
+		Claims:  claims,
+	}, nil
+}
+
+// scopesOf reads the granted scopes from either the space separated "scope" claim or the "scp" array.
+func scopesOf(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	scopes := []string{}
+	if scp, ok := claims["scp"].([]interface{}); ok {
+		for _, s := range scp {
+			if str, ok := s.(string); ok {
+				scopes = append(scopes, str)
+			}
+		}
+	}
+	return scopes
+}
+
+func newHTTPClient(certPath string) (*http.Client, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	if certPath == "" {
+		return client, nil
+	}
+	pem, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate %s: %w", certPath, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", certPath)
+	}
+	client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+	return client, nil
+}
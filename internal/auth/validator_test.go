@@ -0,0 +1,255 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuerPath = "/oauth2/token"
+	testAudience   = "asgardeo-mcp"
+)
+
+// testIssuer is an authorization server that signs tokens with RSA keys and publishes them at a JWKS endpoint.
+type testIssuer struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu      sync.Mutex
+	keys    map[string]*rsa.PrivateKey
+	fetches int
+}
+
+func newTestIssuer(t *testing.T, kids ...string) *testIssuer {
+	t.Helper()
+	issuer := &testIssuer{t: t, keys: map[string]*rsa.PrivateKey{}}
+	for _, kid := range kids {
+		issuer.addKey(kid)
+	}
+	issuer.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+		issuer.fetches++
+		keys := []jsonWebKey{}
+		for kid, key := range issuer.keys {
+			keys = append(keys, jsonWebKey{
+				Kid: kid,
+				Kty: "RSA",
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys}); err != nil {
+			t.Errorf("failed to write JWKS: %v", err)
+		}
+	}))
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// addKey generates a signing key and publishes it under the key ID.
+func (i *testIssuer) addKey(kid string) {
+	i.t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		i.t.Fatalf("failed to generate key: %v", err)
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.keys[kid] = key
+}
+
+func (i *testIssuer) fetchCount() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.fetches
+}
+
+func (i *testIssuer) issuer() string {
+	return i.server.URL + testIssuerPath
+}
+
+// config returns the settings of a validator that trusts the issuer.
+func (i *testIssuer) config() config.AuthConfig {
+	return config.AuthConfig{
+		Enabled:              true,
+		JWKSURL:              i.server.URL + "/oauth2/jwks",
+		Issuer:               i.issuer(),
+		Audience:             testAudience,
+		AuthorizationServers: []string{i.server.URL},
+		JWKSCacheTTL:         time.Hour,
+	}
+}
+
+// claims returns the claims of a token the issuer grants to the test audience.
+func (i *testIssuer) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":   i.issuer(),
+		"sub":   "alice",
+		"aud":   testAudience,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"scope": "internal_application_mgt_view internal_application_mgt_create",
+	}
+}
+
+// sign signs the claims with the key published under the key ID.
+func (i *testIssuer) sign(kid string, claims jwt.MapClaims) string {
+	i.t.Helper()
+	i.mu.Lock()
+	key := i.keys[kid]
+	i.mu.Unlock()
+	if key == nil {
+		i.t.Fatalf("no key with kid %q", kid)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		i.t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func newTestValidator(t *testing.T, cfg config.AuthConfig) *Validator {
+	t.Helper()
+	t.Setenv(config.CERTIFICATE_PATH_PARAM, "")
+	validator, err := NewValidator(cfg)
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+	return validator
+}
+
+func TestValidatorAcceptsValidToken(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	validator := newTestValidator(t, issuer.config())
+
+	token := issuer.sign("key-1", issuer.claims())
+	principal, err := validator.Validate(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if principal.Subject != "alice" {
+		t.Errorf("Subject = %q, want alice", principal.Subject)
+	}
+	if principal.Token != token {
+		t.Errorf("Token = %q, want the validated token", principal.Token)
+	}
+	wantScopes := []string{"internal_application_mgt_view", "internal_application_mgt_create"}
+	if !reflect.DeepEqual(principal.Scopes, wantScopes) {
+		t.Errorf("Scopes = %v, want %v", principal.Scopes, wantScopes)
+	}
+}
+
+func TestValidatorRejectsInvalidClaims(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	validator := newTestValidator(t, issuer.config())
+
+	tests := []struct {
+		name   string
+		modify func(jwt.MapClaims)
+		want   error
+	}{
+		{
+			name:   "wrong issuer",
+			modify: func(c jwt.MapClaims) { c["iss"] = "https://attacker.example.com/oauth2/token" },
+			want:   jwt.ErrTokenInvalidIssuer,
+		},
+		{
+			name:   "wrong audience",
+			modify: func(c jwt.MapClaims) { c["aud"] = "another-api" },
+			want:   jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name:   "expired",
+			modify: func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-clockSkew - time.Minute).Unix() },
+			want:   jwt.ErrTokenExpired,
+		},
+		{
+			name:   "without expiry",
+			modify: func(c jwt.MapClaims) { delete(c, "exp") },
+			want:   jwt.ErrTokenRequiredClaimMissing,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := issuer.claims()
+			tt.modify(claims)
+			_, err := validator.Validate(context.Background(), issuer.sign("key-1", claims))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatorAcceptsTokenWithinClockSkew(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	validator := newTestValidator(t, issuer.config())
+
+	claims := issuer.claims()
+	claims["exp"] = time.Now().Add(-clockSkew / 2).Unix()
+	if _, err := validator.Validate(context.Background(), issuer.sign("key-1", claims)); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestValidatorRejectsTokenOfUnknownSigner(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	other := newTestIssuer(t, "key-1")
+	validator := newTestValidator(t, issuer.config())
+
+	claims := issuer.claims()
+	if _, err := validator.Validate(context.Background(), other.sign("key-1", claims)); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Validate() error = %v, want %v", err, jwt.ErrTokenSignatureInvalid)
+	}
+}
+
+func TestValidatorRejectsUnsignedToken(t *testing.T) {
+	issuer := newTestIssuer(t, "key-1")
+	validator := newTestValidator(t, issuer.config())
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, issuer.claims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("failed to create token: %v", err)
+	}
+	if _, err := validator.Validate(context.Background(), token); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Validate() error = %v, want %v", err, jwt.ErrTokenSignatureInvalid)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuthConfig holds the settings used to protect the HTTP transports with OAuth 2.1 bearer tokens.
+// Insecure serves the HTTP transports without authentication, which the operator must ask for explicitly.
+type AuthConfig struct {
+	Enabled              bool
+	Insecure             bool
+	JWKSURL              string
+	Issuer               string
+	Audience             string
+	ResourceURL          string
+	AuthorizationServers []string
+	RequiredScopes       []string
+	JWKSCacheTTL         time.Duration
+}
+
+// LoadAuth loads the bearer token validation settings from the environment.
+// Authentication is enabled unless turned off, and the issuer and JWKS URL default to the
+// endpoints of the organization of the default profile, so the configuration file must be
+// loaded first.
+func LoadAuth() AuthConfig {
+	cfg := AuthConfig{
+		Enabled:              true,
+		JWKSURL:              os.Getenv(AUTH_JWKS_URL_PARAM),
+		Issuer:               os.Getenv(AUTH_ISSUER_PARAM),
+		Audience:             os.Getenv(AUTH_AUDIENCE_PARAM),
+		ResourceURL:          os.Getenv(AUTH_RESOURCE_URL_PARAM),
+		AuthorizationServers: splitList(os.Getenv(AUTH_AUTHORIZATION_SERVERS_PARAM)),
+		RequiredScopes:       splitList(os.Getenv(AUTH_REQUIRED_SCOPES_PARAM)),
+		JWKSCacheTTL:         DEFAULT_JWKS_CACHE_TTL,
+	}
+	if enabled := os.Getenv(AUTH_ENABLED_PARAM); enabled != "" {
+		value, err := strconv.ParseBool(enabled)
+		if err != nil {
+			log.Printf("Ignoring invalid %s value %q: %v", AUTH_ENABLED_PARAM, enabled, err)
+		} else {
+			cfg.Enabled = value
+		}
+	}
+	if insecure := os.Getenv(INSECURE_NO_AUTH_PARAM); insecure != "" {
+		value, err := strconv.ParseBool(insecure)
+		if err != nil {
+			log.Printf("Ignoring invalid %s value %q: %v", INSECURE_NO_AUTH_PARAM, insecure, err)
+		} else {
+			cfg.Insecure = value
+		}
+	}
+
+	baseURL := strings.TrimSuffix(defaultBaseURL(), "/")
+	if baseURL != "" {
+		if cfg.Issuer == "" {
+			cfg.Issuer = baseURL + "/oauth2/token"
+		}
+		if cfg.JWKSURL == "" {
+			cfg.JWKSURL = baseURL + "/oauth2/jwks"
+		}
+		if len(cfg.AuthorizationServers) == 0 {
+			cfg.AuthorizationServers = []string{baseURL}
+		}
+	}
+	return cfg
+}
+
+// Validate checks that every setting needed to validate tokens is present. Authentication can
+// only be turned off together with Insecure.
+func (c *AuthConfig) Validate() error {
+	if c.Insecure {
+		c.Enabled = false
+		return nil
+	}
+	if !c.Enabled {
+		return fmt.Errorf("bearer token authentication is required on the http and sse transports; set %s=true or, "+
+			"to serve without authentication, pass --insecure-no-auth or set %s=true", AUTH_ENABLED_PARAM, INSECURE_NO_AUTH_PARAM)
+	}
+	var missing []string
+	if c.JWKSURL == "" {
+		missing = append(missing, AUTH_JWKS_URL_PARAM)
+	}
+	if c.Issuer == "" {
+		missing = append(missing, AUTH_ISSUER_PARAM)
+	}
+	if c.Audience == "" {
+		missing = append(missing, AUTH_AUDIENCE_PARAM)
+	}
+	if len(c.AuthorizationServers) == 0 {
+		missing = append(missing, AUTH_AUTHORIZATION_SERVERS_PARAM)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("bearer token authentication is enabled but %s is not set", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// defaultBaseURL returns the base URL of the default profile, which the environment or the
+// configuration file sets.
+func defaultBaseURL() string {
+	profiles, defaultName, err := LoadProfiles()
+	if err != nil {
+		return getBaseURL()
+	}
+	return profiles[defaultName].BaseURL
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -4,33 +4,220 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 )
 
-// Load loads required Asgardeo environment variables and validates them.
-func Load() (baseURL, clientID, clientSecret string, certPath *string, err error) {
-	baseURL = getBaseURL()
-	clientID = getClientID()
-	clientSecret = getClientSecret()
-	certPath = nil
-	if os.Getenv(CERTIFICATE_PATH_PARAM) != "" {
-		certPathValue := os.Getenv(CERTIFICATE_PATH_PARAM)
-		certPath = &certPathValue
+// Profile holds the connection settings of one Asgardeo organization or WSO2 Identity Server tenant.
+type Profile struct {
+	Name         string
+	BaseURL      string
+	ClientID     string
+	ClientSecret string
+	CertPath     *string
+	ProductMode  string
+	Timeout      time.Duration
+}
+
+// Validate checks that the profile has the settings required to connect.
+func (p Profile) Validate() error {
+	var missing []string
+	if p.BaseURL == "" {
+		missing = append(missing, BASE_URL_PARAM)
+	}
+	if p.ClientID == "" {
+		missing = append(missing, CLIENT_ID_PARAM)
+	}
+	if p.ClientSecret == "" {
+		missing = append(missing, CLIENT_SECRET_PARAM)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("profile %q is missing %s", p.Name, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// ProductName returns the display name of the product the profile connects to.
+func (p Profile) ProductName() string {
+	return productName(p.ProductMode)
+}
+
+// LoadProfiles loads every configured profile and the name of the default one. Profiles come from
+// the configuration file and from the environment, which takes precedence field by field: the
+// unprefixed variables (BASE_URL, CLIENT_ID, ...) configure the "default" profile, and the
+// PROFILE_<NAME>_* variables configure the named profile. DEFAULT_PROFILE, then the file's
+// default_profile, selects the default profile; otherwise "default" or the first profile is used.
+func LoadProfiles() (profiles map[string]Profile, defaultName string, err error) {
+	file, err := currentFile()
+	if err != nil {
+		return nil, "", err
+	}
+
+	profiles = map[string]Profile{}
+	if file != nil {
+		for name, fileProfile := range file.Profiles {
+			profiles[name] = fileProfile.toProfile(name, file)
+		}
+	}
+
+	if envProfile := loadDefaultProfile(); envProfile.BaseURL != "" || envProfile.ClientID != "" || envProfile.ClientSecret != "" {
+		profiles[envProfile.Name] = overlayProfile(profiles[envProfile.Name], envProfile, file)
+	}
+	for _, name := range envProfileNames() {
+		envProfile := loadNamedProfile(name)
+		profiles[envProfile.Name] = overlayProfile(profiles[envProfile.Name], envProfile, file)
+	}
+
+	if len(profiles) == 0 {
+		return nil, "", fmt.Errorf("no profiles configured: set BASE_URL, CLIENT_ID and CLIENT_SECRET, or PROFILE_<NAME>_BASE_URL, PROFILE_<NAME>_CLIENT_ID and PROFILE_<NAME>_CLIENT_SECRET, or add profiles to the configuration file")
+	}
+
+	defaultName = strings.ToLower(os.Getenv(DEFAULT_PROFILE_PARAM))
+	if defaultName == "" && file != nil {
+		defaultName = file.DefaultProfile
+	}
+	if defaultName == "" {
+		defaultName = DEFAULT_PROFILE_NAME
+		if _, ok := profiles[defaultName]; !ok {
+			defaultName = ProfileNames(profiles)[0]
+		}
 	}
-	log.Printf("Env loaded: BASE_URL=%q, CLIENT_ID=%q", baseURL, clientID)
-	if baseURL == "" || clientID == "" || clientSecret == "" {
-		err = fmt.Errorf("missing required environment variables BASE_URL, CLIENT_ID, or CLIENT_SECRET")
+	if _, ok := profiles[defaultName]; !ok {
+		return nil, "", fmt.Errorf("default profile %q is not configured; available profiles: %s", defaultName, strings.Join(ProfileNames(profiles), ", "))
 	}
-	return
+	log.Printf("Profiles loaded: %s (default %q)", strings.Join(ProfileNames(profiles), ", "), defaultName)
+	return profiles, defaultName, nil
+}
+
+// ProfileNames returns the sorted names of the given profiles.
+func ProfileNames(profiles map[string]Profile) []string {
+	return sortedKeys(profiles)
 }
 
 func GetProductName() string {
 	productMode := os.Getenv(PRODUCT_MODE_PARAM)
+	if file := loadedFile(); productMode == "" && file != nil {
+		productMode = file.ProductMode
+		if productMode == "" {
+			productMode = file.Profiles[file.DefaultProfile].ProductMode
+		}
+	}
+	return productName(productMode)
+}
+
+func productName(productMode string) string {
 	if productMode == ProductModes.WSO2IS {
 		return ProductNames.WSO2IS
 	}
 	return ProductNames.Asgardeo
 }
 
+func loadDefaultProfile() Profile {
+	return Profile{
+		Name:         DEFAULT_PROFILE_NAME,
+		BaseURL:      getBaseURL(),
+		ClientID:     getClientID(),
+		ClientSecret: getClientSecret(),
+		CertPath:     getOptionalEnv(CERTIFICATE_PATH_PARAM),
+		ProductMode:  os.Getenv(PRODUCT_MODE_PARAM),
+		Timeout:      DEFAULT_CLIENT_TIMEOUT,
+	}
+}
+
+func loadNamedProfile(name string) Profile {
+	prefix := PROFILE_PARAM_PREFIX + name + "_"
+	return Profile{
+		Name:         strings.ToLower(name),
+		BaseURL:      os.Getenv(prefix + BASE_URL_PARAM),
+		ClientID:     os.Getenv(prefix + CLIENT_ID_PARAM),
+		ClientSecret: os.Getenv(prefix + CLIENT_SECRET_PARAM),
+		CertPath:     getOptionalEnv(prefix + CERTIFICATE_PATH_PARAM),
+		ProductMode:  os.Getenv(prefix + PRODUCT_MODE_PARAM),
+		Timeout:      DEFAULT_CLIENT_TIMEOUT,
+	}
+}
+
+// envProfileNames returns the names of the profiles configured with PROFILE_<NAME>_* variables.
+func envProfileNames() []string {
+	params := []string{BASE_URL_PARAM, CLIENT_ID_PARAM, CLIENT_SECRET_PARAM, CERTIFICATE_PATH_PARAM, PRODUCT_MODE_PARAM}
+	names := map[string]bool{}
+	for _, env := range os.Environ() {
+		key, _, _ := strings.Cut(env, "=")
+		if !strings.HasPrefix(key, PROFILE_PARAM_PREFIX) {
+			continue
+		}
+		for _, param := range params {
+			if name, ok := strings.CutSuffix(strings.TrimPrefix(key, PROFILE_PARAM_PREFIX), "_"+param); ok && name != "" {
+				names[name] = true
+			}
+		}
+	}
+	return sortedKeys(names)
+}
+
+// overlayProfile applies the non-empty settings of the environment on top of the profile from the
+// configuration file. Settings missing from both fall back to the file's global defaults.
+func overlayProfile(base, env Profile, file *FileConfig) Profile {
+	if base.Name == "" {
+		base = FileProfileConfig{}.toProfile(env.Name, file)
+	}
+	if env.BaseURL != "" {
+		base.BaseURL = env.BaseURL
+	}
+	if env.ClientID != "" {
+		base.ClientID = env.ClientID
+	}
+	if env.ClientSecret != "" {
+		base.ClientSecret = env.ClientSecret
+	}
+	if env.CertPath != nil {
+		base.CertPath = env.CertPath
+	}
+	if env.ProductMode != "" {
+		base.ProductMode = env.ProductMode
+	}
+	return base
+}
+
+// toProfile converts the file settings of a profile, falling back to the file's global defaults.
+func (p FileProfileConfig) toProfile(name string, file *FileConfig) Profile {
+	profile := Profile{
+		Name:         name,
+		BaseURL:      p.BaseURL,
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		ProductMode:  p.ProductMode,
+		Timeout:      DEFAULT_CLIENT_TIMEOUT,
+	}
+	certPath, timeout := p.CertPath, p.Timeout
+	if file != nil {
+		if certPath == "" {
+			certPath = file.CABundle
+		}
+		if timeout == "" {
+			timeout = file.Timeout
+		}
+		if profile.ProductMode == "" {
+			profile.ProductMode = file.ProductMode
+		}
+	}
+	if certPath != "" {
+		profile.CertPath = &certPath
+	}
+	// Timeouts are validated when the file is read.
+	if d, err := time.ParseDuration(timeout); err == nil {
+		profile.Timeout = d
+	}
+	return profile
+}
+
+func getOptionalEnv(key string) *string {
+	if value := os.Getenv(key); value != "" {
+		return &value
+	}
+	return nil
+}
+
 func getBaseURL() string {
 	baseURL := os.Getenv(BASE_URL_PARAM)
 	if baseURL == "" {
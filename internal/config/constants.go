@@ -18,6 +18,8 @@
 
 package config
 
+import "time"
+
 const (
 	BASE_URL_PARAM         = "BASE_URL"
 	CLIENT_ID_PARAM        = "CLIENT_ID"
@@ -26,6 +28,159 @@ const (
 	PRODUCT_MODE_PARAM     = "PRODUCT_MODE"
 )
 
+// Profile related environment variables. Named profiles are configured with
+// PROFILE_<NAME>_<PARAM>, e.g. PROFILE_STAGING_BASE_URL.
+const (
+	PROFILE_PARAM_PREFIX  = "PROFILE_"
+	DEFAULT_PROFILE_PARAM = "DEFAULT_PROFILE"
+	DEFAULT_PROFILE_NAME  = "default"
+)
+
+// Configuration file related settings
+const (
+	CONFIG_FILE_PARAM      = "CONFIG_FILE"
+	CONFIG_DIR_NAME        = "asgardeo-mcp"
+	DEFAULT_CLIENT_TIMEOUT = 10 * time.Second
+)
+
+// CONFIG_FILE_NAMES are the file names looked up in the configuration directory, in order.
+var CONFIG_FILE_NAMES = []string{"config.yaml", "config.yml", "config.json"}
+
+// Tool selection related environment variables
+const (
+	READ_ONLY_PARAM     = "READ_ONLY"
+	TOOLS_INCLUDE_PARAM = "TOOLS_INCLUDE"
+	TOOLS_EXCLUDE_PARAM = "TOOLS_EXCLUDE"
+)
+
+// ToolCategories group the tools by the kind of object they manage, so that
+// they can be included or excluded together.
+var ToolCategories = struct {
+	Applications string
+	APIResources string
+	Users        string
+	Claims       string
+	Server       string
+}{
+	Applications: "applications",
+	APIResources: "api_resources",
+	Users:        "users",
+	Claims:       "claims",
+	Server:       "server",
+}
+
+// Listing related settings
+const (
+	// DEFAULT_MAX_RESPONSE_BYTES is the size budget of a listing when the tool call does not set max_bytes.
+	DEFAULT_MAX_RESPONSE_BYTES = 100000
+	// LIST_ALL_LIMIT_PARAM caps the number of items that a list tool reads when called with all=true.
+	LIST_ALL_LIMIT_PARAM   = "LIST_ALL_LIMIT"
+	DEFAULT_LIST_ALL_LIMIT = 1000
+	// LIST_ALL_PAGE_SIZE is the page size used to read every item of a listing.
+	LIST_ALL_PAGE_SIZE = 100
+)
+
+// BULK_DELETE_LIMIT is the maximum number of applications that delete_application removes in one call.
+const BULK_DELETE_LIMIT = 10
+
+// OIDC discovery related settings
+const (
+	// OIDC_DISCOVERY_CACHE_TTL is how long the endpoints read from a discovery document are reused.
+	OIDC_DISCOVERY_CACHE_TTL = time.Hour
+	// OIDC_DISCOVERY_RETRY_INTERVAL is how long the static endpoints are used after discovery failed.
+	OIDC_DISCOVERY_RETRY_INTERVAL = 5 * time.Minute
+)
+
+// Secret handling related environment variables
+const (
+	SECRET_SINK_PARAM = "SECRET_SINK"
+	SECRETS_DIR_PARAM = "SECRETS_DIR"
+	SECRETS_DIR_NAME  = "secrets"
+)
+
+// SecretSinks are the destinations of the client secrets issued by the tools.
+var SecretSinks = struct {
+	Redact string
+	Env    string
+	Dotenv string
+	File   string
+	Stdout string
+}{
+	Redact: "redact",
+	Env:    "env",
+	Dotenv: "dotenv",
+	File:   "file",
+	Stdout: "stdout",
+}
+
+// SAML related environment variables
+const (
+	// SAML_METADATA_ALLOWED_HOSTS_PARAM lists the host names, IP addresses and CIDR ranges that metadata_url
+	// may point to although they are not public.
+	SAML_METADATA_ALLOWED_HOSTS_PARAM = "SAML_METADATA_ALLOWED_HOSTS"
+)
+
+// Resource subscription related environment variables
+const (
+	RESOURCE_POLL_INTERVAL_PARAM   = "RESOURCE_POLL_INTERVAL"
+	DEFAULT_RESOURCE_POLL_INTERVAL = 60 * time.Second
+)
+
+// Transport related environment variables
+const (
+	TRANSPORT_PARAM                 = "TRANSPORT"
+	HTTP_ADDR_PARAM                 = "HTTP_ADDR"
+	HTTP_BASE_PATH_PARAM            = "HTTP_BASE_PATH"
+	HTTP_STATELESS_PARAM            = "HTTP_STATELESS"
+	HTTP_HEARTBEAT_INTERVAL_PARAM   = "HTTP_HEARTBEAT_INTERVAL"
+	HTTP_SHUTDOWN_TIMEOUT_PARAM     = "HTTP_SHUTDOWN_TIMEOUT"
+	DEFAULT_HTTP_ADDR               = ":8000"
+	DEFAULT_HTTP_BASE_PATH          = "/mcp"
+	DEFAULT_HTTP_SHUTDOWN_TIMEOUT   = 10 * time.Second
+	DEFAULT_HTTP_HEARTBEAT_INTERVAL = 30 * time.Second
+)
+
+// Authentication related environment variables for the HTTP transports
+const (
+	AUTH_ENABLED_PARAM               = "AUTH_ENABLED"
+	AUTH_JWKS_URL_PARAM              = "AUTH_JWKS_URL"
+	AUTH_ISSUER_PARAM                = "AUTH_ISSUER"
+	AUTH_AUDIENCE_PARAM              = "AUTH_AUDIENCE"
+	AUTH_RESOURCE_URL_PARAM          = "AUTH_RESOURCE_URL"
+	AUTH_AUTHORIZATION_SERVERS_PARAM = "AUTH_AUTHORIZATION_SERVERS"
+	AUTH_REQUIRED_SCOPES_PARAM       = "AUTH_REQUIRED_SCOPES"
+	INSECURE_NO_AUTH_PARAM           = "INSECURE_NO_AUTH"
+	DEFAULT_JWKS_CACHE_TTL           = 15 * time.Minute
+)
+
+// Token exchange related environment variables for delegated credentials
+const (
+	TOKEN_EXCHANGE_ENABLED_PARAM            = "TOKEN_EXCHANGE_ENABLED"
+	TOKEN_EXCHANGE_SCOPES_PARAM             = "TOKEN_EXCHANGE_SCOPES"
+	TOKEN_EXCHANGE_SUBJECT_TOKEN_TYPE_PARAM = "TOKEN_EXCHANGE_SUBJECT_TOKEN_TYPE"
+	TOKEN_EXCHANGE_GRANT_TYPE               = "urn:ietf:params:oauth:grant-type:token-exchange"
+	TOKEN_TYPE_ACCESS_TOKEN                 = "urn:ietf:params:oauth:token-type:access_token"
+	TOKEN_TYPE_JWT                          = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// DefaultManagementScopes are the scopes requested for delegated management API tokens: every
+// scope that the registered tools need, as listed in the README.
+var DefaultManagementScopes = []string{
+	"internal_application_mgt_view",
+	"internal_application_mgt_update",
+	"internal_application_mgt_create",
+	"internal_application_mgt_delete",
+	"internal_api_resource_view",
+	"internal_api_resource_update",
+	"internal_api_resource_create",
+	"internal_idp_view",
+	"internal_authenticator_view",
+	"internal_claim_meta_view",
+	"internal_user_mgt_create",
+	"internal_oidc_scope_mgt_view",
+	"internal_organization_view",
+}
+
 // Deprecated constants for backward compatibility
 const (
 	ASGARDEO_BASE_URL_PARAM      = "ASGARDEO_BASE_URL"
@@ -41,6 +196,16 @@ var ProductModes = struct {
 	Asgardeo: "asgardeo",
 }
 
+var TransportModes = struct {
+	Stdio string
+	HTTP  string
+	SSE   string
+}{
+	Stdio: "stdio",
+	HTTP:  "http",
+	SSE:   "sse",
+}
+
 var ProductNames = struct {
 	WSO2IS   string
 	Asgardeo string
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+// DelegationConfig holds the settings used to exchange a caller's token for a
+// management API token (RFC 8693) so that actions are performed on their behalf.
+type DelegationConfig struct {
+	Enabled          bool
+	Scopes           []string
+	SubjectTokenType string
+}
+
+// LoadDelegation loads the token exchange settings from the environment.
+func LoadDelegation() DelegationConfig {
+	cfg := DelegationConfig{
+		Scopes:           splitList(os.Getenv(TOKEN_EXCHANGE_SCOPES_PARAM)),
+		SubjectTokenType: os.Getenv(TOKEN_EXCHANGE_SUBJECT_TOKEN_TYPE_PARAM),
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = DefaultManagementScopes
+	}
+	if cfg.SubjectTokenType == "" {
+		cfg.SubjectTokenType = TOKEN_TYPE_JWT
+	}
+	if enabled := os.Getenv(TOKEN_EXCHANGE_ENABLED_PARAM); enabled != "" {
+		value, err := strconv.ParseBool(enabled)
+		if err != nil {
+			log.Printf("Ignoring invalid %s value %q: %v", TOKEN_EXCHANGE_ENABLED_PARAM, enabled, err)
+		} else {
+			cfg.Enabled = value
+		}
+	}
+	return cfg
+}
+
+// Validate checks that delegation is only enabled together with bearer token authentication.
+func (c *DelegationConfig) Validate(auth AuthConfig) error {
+	if !c.Enabled {
+		return nil
+	}
+	if !auth.Enabled {
+		return fmt.Errorf("token exchange requires bearer token authentication to be enabled")
+	}
+	if c.SubjectTokenType != TOKEN_TYPE_JWT && c.SubjectTokenType != TOKEN_TYPE_ACCESS_TOKEN {
+		return fmt.Errorf("unsupported subject token type %q", c.SubjectTokenType)
+	}
+	return nil
+}
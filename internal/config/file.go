@@ -0,0 +1,423 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig is the content of the YAML or JSON configuration file.
+type FileConfig struct {
+	DefaultProfile string                       `yaml:"default_profile"`
+	ProductMode    string                       `yaml:"product_mode"`
+	CABundle       string                       `yaml:"ca_bundle"`
+	Timeout        string                       `yaml:"timeout"`
+	Profiles       map[string]FileProfileConfig `yaml:"profiles"`
+	Tools          FileToolsConfig              `yaml:"tools"`
+	Logging        FileLoggingConfig            `yaml:"logging"`
+	Prompts        []FilePromptConfig           `yaml:"prompts"`
+	Secrets        FileSecretsConfig            `yaml:"secrets"`
+	Resources      FileResourcesConfig          `yaml:"resources"`
+}
+
+// FileProfileConfig holds the connection settings of one profile in the configuration file.
+type FileProfileConfig struct {
+	BaseURL      string `yaml:"base_url"`
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	CertPath     string `yaml:"cert_path"`
+	ProductMode  string `yaml:"product_mode"`
+	Timeout      string `yaml:"timeout"`
+}
+
+// FileToolsConfig selects the tools exposed by the server. Entries are tool names,
+// glob patterns over tool names or tool categories.
+type FileToolsConfig struct {
+	ReadOnly bool     `yaml:"read_only"`
+	Enabled  []string `yaml:"enabled"`
+	Disabled []string `yaml:"disabled"`
+}
+
+// FileLoggingConfig controls where the server writes its logs.
+type FileLoggingConfig struct {
+	File     string `yaml:"file"`
+	Disabled bool   `yaml:"disabled"`
+}
+
+// FileSecretsConfig controls how the client secrets issued by the tools are handled.
+type FileSecretsConfig struct {
+	Sink string `yaml:"sink"`
+	Dir  string `yaml:"dir"`
+}
+
+// FileResourcesConfig controls the notifications of subscribed resources.
+type FileResourcesConfig struct {
+	PollInterval string `yaml:"poll_interval"`
+}
+
+// FilePromptConfig defines a custom prompt template. The template is a Go template in which
+// each argument is available as {{.<name>}}.
+type FilePromptConfig struct {
+	Name        string                     `yaml:"name"`
+	Description string                     `yaml:"description"`
+	Arguments   []FilePromptArgumentConfig `yaml:"arguments"`
+	Template    string                     `yaml:"template"`
+}
+
+// FilePromptArgumentConfig defines an argument of a custom prompt template.
+type FilePromptArgumentConfig struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Default     string `yaml:"default"`
+}
+
+// ValidationError lists every problem found in the configuration file.
+type ValidationError struct {
+	Path     string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration file %s:\n  - %s", e.Path, strings.Join(e.Problems, "\n  - "))
+}
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+var (
+	fileMu     sync.RWMutex
+	filePath   string
+	fileConfig *FileConfig
+)
+
+// LoadFile loads the configuration file. An empty path looks the file up in the standard
+// configuration directory (e.g. ~/.config/asgardeo-mcp/config.yaml); it is not an error if
+// no file exists there. Once loaded, the file is read again whenever the profiles are reloaded.
+func LoadFile(path string) error {
+	if path == "" {
+		path = os.Getenv(CONFIG_FILE_PARAM)
+	}
+	if path == "" {
+		path = findDefaultFile()
+		if path == "" {
+			return nil
+		}
+	}
+
+	cfg, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	fileMu.Lock()
+	filePath, fileConfig = path, cfg
+	fileMu.Unlock()
+	log.Printf("Configuration loaded from %s", path)
+	return nil
+}
+
+// currentFile returns the configuration file, reading it again so that changes are picked up on reload.
+func currentFile() (*FileConfig, error) {
+	fileMu.RLock()
+	path := filePath
+	fileMu.RUnlock()
+	if path == "" {
+		return nil, nil
+	}
+
+	cfg, err := readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fileMu.Lock()
+	fileConfig = cfg
+	fileMu.Unlock()
+	return cfg, nil
+}
+
+// loadedFile returns the configuration file as last read, or nil when there is none.
+func loadedFile() *FileConfig {
+	fileMu.RLock()
+	defer fileMu.RUnlock()
+	return fileConfig
+}
+
+func findDefaultFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	for _, name := range CONFIG_FILE_NAMES {
+		path := filepath.Join(dir, CONFIG_DIR_NAME, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// readFile reads, parses, interpolates and validates the configuration file.
+// JSON files are parsed with the YAML decoder, as JSON is a subset of YAML.
+func readFile(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file: %w", err)
+	}
+
+	problems := []string{}
+	cfg := &FileConfig{}
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		problems = append(problems, err.Error())
+	} else if len(document.Content) > 0 {
+		// Environment references are replaced in the parsed values, so that a value cannot change
+		// the structure of the file.
+		problems = append(problems, interpolate(&document)...)
+		if data, err = yaml.Marshal(&document); err != nil {
+			return nil, fmt.Errorf("failed to interpolate configuration file: %w", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	problems = append(problems, cfg.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Path: path, Problems: problems}
+	}
+	return cfg, nil
+}
+
+// interpolate replaces the ${NAME} and ${NAME:-default} references in the string values of the
+// node with the environment variables they name, and returns the variables that are not set.
+func interpolate(node *yaml.Node) []string {
+	problems := []string{}
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			problems = append(problems, interpolate(child)...)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			problems = append(problems, interpolate(node.Content[i])...)
+		}
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" || !envReference.MatchString(node.Value) {
+			return problems
+		}
+		node.Value = envReference.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			match := envReference.FindStringSubmatch(ref)
+			if value, ok := os.LookupEnv(match[1]); ok {
+				return value
+			}
+			if match[2] != "" {
+				return match[3]
+			}
+			problems = append(problems, fmt.Sprintf("environment variable %s referenced in the file is not set", match[1]))
+			return ""
+		})
+		// A plain value is resolved again, e.g. as a boolean, while a quoted value stays a string.
+		node.Tag = ""
+	}
+	return problems
+}
+
+// validate returns every problem found in the configuration.
+func (c *FileConfig) validate() []string {
+	problems := []string{}
+	checkProductMode := func(field, mode string) {
+		if mode != "" && mode != ProductModes.Asgardeo && mode != ProductModes.WSO2IS {
+			problems = append(problems, fmt.Sprintf("%s: unsupported product mode %q, expected %q or %q", field, mode, ProductModes.Asgardeo, ProductModes.WSO2IS))
+		}
+	}
+	checkTimeout := func(field, timeout string) {
+		if timeout == "" {
+			return
+		}
+		if d, err := time.ParseDuration(timeout); err != nil || d <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: invalid timeout %q, expected a positive duration such as \"10s\"", field, timeout))
+		}
+	}
+	checkFile := func(field, path string) {
+		if path == "" {
+			return
+		}
+		if _, err := os.Stat(path); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", field, err))
+		}
+	}
+
+	checkProductMode("product_mode", c.ProductMode)
+	checkTimeout("timeout", c.Timeout)
+	checkFile("ca_bundle", c.CABundle)
+
+	for _, name := range sortedKeys(c.Profiles) {
+		profile := c.Profiles[name]
+		field := "profiles." + name
+		if name != strings.ToLower(name) {
+			problems = append(problems, fmt.Sprintf("%s: profile names must be lower case", field))
+		}
+		if profile.BaseURL == "" {
+			if !hasEnvOverride(name, BASE_URL_PARAM) {
+				problems = append(problems, field+".base_url is required")
+			}
+		} else if !strings.HasPrefix(profile.BaseURL, "https://") && !strings.HasPrefix(profile.BaseURL, "http://") {
+			problems = append(problems, fmt.Sprintf("%s.base_url: %q is not an http(s) URL", field, profile.BaseURL))
+		}
+		if profile.ClientID == "" && !hasEnvOverride(name, CLIENT_ID_PARAM) {
+			problems = append(problems, field+".client_id is required")
+		}
+		if profile.ClientSecret == "" && !hasEnvOverride(name, CLIENT_SECRET_PARAM) {
+			problems = append(problems, field+".client_secret is required")
+		}
+		checkProductMode(field+".product_mode", profile.ProductMode)
+		checkTimeout(field+".timeout", profile.Timeout)
+		checkFile(field+".cert_path", profile.CertPath)
+	}
+
+	if c.DefaultProfile != "" {
+		if _, ok := c.Profiles[c.DefaultProfile]; !ok && c.DefaultProfile != DEFAULT_PROFILE_NAME {
+			problems = append(problems, fmt.Sprintf("default_profile: profile %q is not defined", c.DefaultProfile))
+		}
+	}
+	for _, name := range c.Tools.Enabled {
+		for _, disabled := range c.Tools.Disabled {
+			if name == disabled {
+				problems = append(problems, fmt.Sprintf("tools: %q is both enabled and disabled", name))
+			}
+		}
+	}
+	for _, pattern := range append(append([]string{}, c.Tools.Enabled...), c.Tools.Disabled...) {
+		if err := validatePattern(pattern); err != nil {
+			problems = append(problems, fmt.Sprintf("tools: %v", err))
+		}
+	}
+	if c.Secrets.Sink != "" && !IsSecretSink(c.Secrets.Sink) {
+		problems = append(problems, fmt.Sprintf("secrets.sink: unsupported sink %q, expected one of %s", c.Secrets.Sink, strings.Join(SecretSinkNames(), ", ")))
+	}
+	if interval := c.Resources.PollInterval; interval != "" {
+		if d, err := time.ParseDuration(interval); err != nil || d < 0 {
+			problems = append(problems, fmt.Sprintf("resources.poll_interval: invalid interval %q, expected a duration such as \"60s\", or 0 to disable polling", interval))
+		}
+	}
+	problems = append(problems, validatePrompts(c.Prompts)...)
+	return problems
+}
+
+// validatePrompts returns every problem found in the custom prompt templates.
+func validatePrompts(prompts []FilePromptConfig) []string {
+	problems := []string{}
+	names := map[string]bool{}
+	for i, prompt := range prompts {
+		field := fmt.Sprintf("prompts[%d]", i)
+		if prompt.Name == "" {
+			problems = append(problems, field+".name is required")
+		} else if names[prompt.Name] {
+			problems = append(problems, fmt.Sprintf("%s: prompt %q is defined more than once", field, prompt.Name))
+		}
+		names[prompt.Name] = true
+
+		if prompt.Template == "" {
+			problems = append(problems, field+".template is required")
+		} else if _, err := template.New(prompt.Name).Parse(prompt.Template); err != nil {
+			problems = append(problems, fmt.Sprintf("%s.template: %v", field, err))
+		}
+
+		arguments := map[string]bool{}
+		for j, argument := range prompt.Arguments {
+			if argument.Name == "" {
+				problems = append(problems, fmt.Sprintf("%s.arguments[%d].name is required", field, j))
+			} else if arguments[argument.Name] {
+				problems = append(problems, fmt.Sprintf("%s.arguments[%d]: argument %q is defined more than once", field, j, argument.Name))
+			}
+			arguments[argument.Name] = true
+		}
+	}
+	return problems
+}
+
+// LoadPromptTemplates returns the custom prompt templates of the configuration file.
+func LoadPromptTemplates() []FilePromptConfig {
+	if file := loadedFile(); file != nil {
+		return file.Prompts
+	}
+	return nil
+}
+
+// ConfigureLogging applies the logging settings of the configuration file.
+func ConfigureLogging() error {
+	cfg := loadedFile()
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Logging.Disabled {
+		log.SetOutput(io.Discard)
+		return nil
+	}
+	if cfg.Logging.File != "" {
+		file, err := os.OpenFile(cfg.Logging.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open log file: %w", err)
+		}
+		log.SetOutput(file)
+	}
+	return nil
+}
+
+// hasEnvOverride reports whether the environment sets the given parameter of the profile. The
+// default profile is also set by the unprefixed variables and their ASGARDEO_* fallbacks.
+func hasEnvOverride(profile, param string) bool {
+	if profile == DEFAULT_PROFILE_NAME {
+		env := loadDefaultProfile()
+		overrides := map[string]string{
+			BASE_URL_PARAM:      env.BaseURL,
+			CLIENT_ID_PARAM:     env.ClientID,
+			CLIENT_SECRET_PARAM: env.ClientSecret,
+		}
+		if overrides[param] != "" {
+			return true
+		}
+	}
+	return os.Getenv(PROFILE_PARAM_PREFIX+strings.ToUpper(profile)+"_"+param) != ""
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// GetListAllLimit returns the maximum number of items that a list tool reads in all=true mode.
+func GetListAllLimit() int {
+	raw := os.Getenv(LIST_ALL_LIMIT_PARAM)
+	if raw == "" {
+		return DEFAULT_LIST_ALL_LIMIT
+	}
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		log.Printf("Ignoring invalid %s value %q: expected a positive integer", LIST_ALL_LIMIT_PARAM, raw)
+		return DEFAULT_LIST_ALL_LIMIT
+	}
+	return limit
+}
@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import "time"
+
+// GetResourcePollInterval returns the interval at which subscribed resources are polled for changes,
+// from the environment or else the configuration file. Zero disables polling; changes made through
+// this server's own tools are still notified.
+func GetResourcePollInterval() time.Duration {
+	interval := DEFAULT_RESOURCE_POLL_INTERVAL
+	if file := loadedFile(); file != nil && file.Resources.PollInterval != "" {
+		// The interval is validated when the file is read.
+		if d, err := time.ParseDuration(file.Resources.PollInterval); err == nil {
+			interval = d
+		}
+	}
+	return getDurationWithDefault(RESOURCE_POLL_INTERVAL_PARAM, interval)
+}
@@ -0,0 +1,27 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import "os"
+
+// GetSAMLMetadataAllowedHosts returns the non-public host names, IP addresses and CIDR ranges that
+// the SP metadata of SAML applications may be fetched from.
+func GetSAMLMetadataAllowedHosts() []string {
+	return splitList(os.Getenv(SAML_METADATA_ALLOWED_HOSTS_PARAM))
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// SecretPolicy controls how the client secrets issued by the tools are handled.
+type SecretPolicy struct {
+	// Sink is the destination of a secret when the tool call does not choose one.
+	Sink string
+	// Dir is the directory in which the file sink writes. It is empty when it cannot be determined.
+	Dir string
+}
+
+// LoadSecretPolicy loads the secret policy from the configuration file and the environment.
+// SECRET_SINK and SECRETS_DIR take precedence over the file's secrets section. Secrets are
+// redacted unless configured otherwise, and the file sink writes under the secrets directory
+// of the user configuration directory by default.
+func LoadSecretPolicy() SecretPolicy {
+	policy := SecretPolicy{Sink: SecretSinks.Redact}
+	if dir, err := os.UserConfigDir(); err == nil {
+		policy.Dir = filepath.Join(dir, CONFIG_DIR_NAME, SECRETS_DIR_NAME)
+	}
+	if file := loadedFile(); file != nil {
+		if file.Secrets.Sink != "" {
+			policy.Sink = file.Secrets.Sink
+		}
+		if file.Secrets.Dir != "" {
+			policy.Dir = file.Secrets.Dir
+		}
+	}
+
+	if sink := os.Getenv(SECRET_SINK_PARAM); sink != "" {
+		if IsSecretSink(sink) {
+			policy.Sink = sink
+		} else {
+			log.Printf("Ignoring invalid %s value %q", SECRET_SINK_PARAM, sink)
+		}
+	}
+	if dir := os.Getenv(SECRETS_DIR_PARAM); dir != "" {
+		policy.Dir = dir
+	}
+	return policy
+}
+
+// SecretSinkNames returns the names of the secret sinks.
+func SecretSinkNames() []string {
+	return []string{SecretSinks.Redact, SecretSinks.Env, SecretSinks.Dotenv, SecretSinks.File, SecretSinks.Stdout}
+}
+
+// IsSecretSink reports whether the name is one of the secret sinks.
+func IsSecretSink(name string) bool {
+	for _, sink := range SecretSinkNames() {
+		if sink == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strconv"
+)
+
+// ToolFilter selects the tools registered by the server.
+type ToolFilter struct {
+	// ReadOnly registers only the tools that do not modify the organization.
+	ReadOnly bool
+	// Include, when not empty, registers only the tools matching one of its patterns.
+	Include []string
+	// Exclude drops the tools matching one of its patterns, even if they are included.
+	Exclude []string
+}
+
+// LoadToolFilter loads the tool selection from the configuration file and the environment.
+// READ_ONLY, TOOLS_INCLUDE and TOOLS_EXCLUDE take precedence over the file's tools section.
+func LoadToolFilter() ToolFilter {
+	filter := ToolFilter{}
+	if file := loadedFile(); file != nil {
+		filter.ReadOnly = file.Tools.ReadOnly
+		filter.Include = file.Tools.Enabled
+		filter.Exclude = file.Tools.Disabled
+	}
+
+	if readOnly := os.Getenv(READ_ONLY_PARAM); readOnly != "" {
+		value, err := strconv.ParseBool(readOnly)
+		if err != nil {
+			log.Printf("Ignoring invalid %s value %q: %v", READ_ONLY_PARAM, readOnly, err)
+		} else {
+			filter.ReadOnly = value
+		}
+	}
+	if include := splitList(os.Getenv(TOOLS_INCLUDE_PARAM)); len(include) > 0 {
+		filter.Include = include
+	}
+	if exclude := splitList(os.Getenv(TOOLS_EXCLUDE_PARAM)); len(exclude) > 0 {
+		filter.Exclude = exclude
+	}
+	return filter
+}
+
+// Validate checks that every pattern of the filter is well formed.
+func (f ToolFilter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Include...), f.Exclude...) {
+		if err := validatePattern(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Allows reports whether a tool with the given name, category and read-only hint is registered.
+// A pattern matches a tool when it equals the tool's category or matches its name as a glob
+// pattern, e.g. "applications", "create_*" or "get_application_by_name".
+func (f ToolFilter) Allows(name, category string, readOnly bool) bool {
+	if f.ReadOnly && !readOnly {
+		return false
+	}
+	if matchesAny(f.Exclude, name, category) {
+		return false
+	}
+	return len(f.Include) == 0 || matchesAny(f.Include, name, category)
+}
+
+func matchesAny(patterns []string, name, category string) bool {
+	for _, pattern := range patterns {
+		if pattern == category {
+			return true
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid tool pattern %q: %v", pattern, err)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// activeTransportMode is the transport the server was started with, after the command line flags.
+var activeTransportMode atomic.Value
+
+// TransportConfig holds the settings used to expose the MCP server.
+type TransportConfig struct {
+	Mode              string
+	Addr              string
+	BasePath          string
+	Stateless         bool
+	HeartbeatInterval time.Duration
+	ShutdownTimeout   time.Duration
+	Auth              AuthConfig
+	Delegation        DelegationConfig
+}
+
+// LoadTransport loads the transport settings from the environment, falling back to defaults.
+func LoadTransport() TransportConfig {
+	cfg := TransportConfig{
+		Mode:              GetTransportMode(),
+		Addr:              DEFAULT_HTTP_ADDR,
+		BasePath:          DEFAULT_HTTP_BASE_PATH,
+		HeartbeatInterval: DEFAULT_HTTP_HEARTBEAT_INTERVAL,
+		ShutdownTimeout:   DEFAULT_HTTP_SHUTDOWN_TIMEOUT,
+		Auth:              LoadAuth(),
+		Delegation:        LoadDelegation(),
+	}
+	if addr := os.Getenv(HTTP_ADDR_PARAM); addr != "" {
+		cfg.Addr = addr
+	}
+	if basePath := os.Getenv(HTTP_BASE_PATH_PARAM); basePath != "" {
+		cfg.BasePath = basePath
+	}
+	if stateless := os.Getenv(HTTP_STATELESS_PARAM); stateless != "" {
+		value, err := strconv.ParseBool(stateless)
+		if err != nil {
+			log.Printf("Ignoring invalid %s value %q: %v", HTTP_STATELESS_PARAM, stateless, err)
+		} else {
+			cfg.Stateless = value
+		}
+	}
+	cfg.HeartbeatInterval = getDurationWithDefault(HTTP_HEARTBEAT_INTERVAL_PARAM, cfg.HeartbeatInterval)
+	cfg.ShutdownTimeout = getDurationWithDefault(HTTP_SHUTDOWN_TIMEOUT_PARAM, cfg.ShutdownTimeout)
+	return cfg
+}
+
+// GetTransportMode returns the configured transport, stdio by default.
+func GetTransportMode() string {
+	if mode := os.Getenv(TRANSPORT_PARAM); mode != "" {
+		return mode
+	}
+	return TransportModes.Stdio
+}
+
+// SetActiveTransportMode records the transport the server is started with, which the command line
+// may have chosen over the environment.
+func SetActiveTransportMode(mode string) {
+	activeTransportMode.Store(mode)
+}
+
+// ActiveTransportMode returns the transport the server was started with, or the configured
+// transport before the server is started.
+func ActiveTransportMode() string {
+	if mode, ok := activeTransportMode.Load().(string); ok {
+		return mode
+	}
+	return GetTransportMode()
+}
+
+// Validate checks that the transport settings are usable.
+func (c *TransportConfig) Validate() error {
+	switch c.Mode {
+	case TransportModes.Stdio:
+		return nil
+	case TransportModes.HTTP, TransportModes.SSE:
+	default:
+		return fmt.Errorf("unsupported transport %q: expected one of %s, %s or %s",
+			c.Mode, TransportModes.Stdio, TransportModes.HTTP, TransportModes.SSE)
+	}
+	if c.Addr == "" {
+		return fmt.Errorf("listen address is required for the %s transport", c.Mode)
+	}
+	c.BasePath = "/" + strings.Trim(c.BasePath, "/")
+	if c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("shutdown timeout must be positive")
+	}
+	if err := c.Auth.Validate(); err != nil {
+		return err
+	}
+	return c.Delegation.Validate(c.Auth)
+}
+
+func getDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultValue
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Ignoring invalid %s value %q: %v", key, raw, err)
+		return defaultValue
+	}
+	return value
+}
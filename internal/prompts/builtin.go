@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package prompts
+
+// builtinTemplates are the prompts for the common onboarding workflows.
+var builtinTemplates = []Template{
+	{
+		Name:        "secure_react_spa",
+		Description: "Secure a React single page application with sign-in",
+		Arguments: []Argument{
+			{Name: "app_name", Description: "Name of the application", Required: true},
+			{Name: "redirect_url", Description: "URL the users are redirected to after signing in", Default: "http://localhost:5173"},
+			{Name: "allowed_origins", Description: "Comma-separated origins allowed to call the token endpoint, defaults to the redirect URL's origin"},
+		},
+		Text: `Secure the React single page application "{{.app_name}}" with {{.product}}.
+
+1. Check with get_application_by_name whether an application named "{{.app_name}}" already exists. If it does, ask me before changing it instead of creating a new one.
+2. Call create_single_page_app with application_name "{{.app_name}}" and redirect_url "{{.redirect_url}}".
+3. Call update_application_oauth_config for the new application with redirect_urls ["{{.redirect_url}}"] and allowed_origins set to {{if .allowed_origins}}the origins {{.allowed_origins}}{{else}}the origin of {{.redirect_url}}{{end}}, so that the browser can call the token endpoint.
+4. Show me the client ID and the OIDC endpoints of the application, and the configuration for the Asgardeo React SDK (clientId, baseUrl, signInRedirectURL and signOutRedirectURL) with a short example of wrapping the app in the AuthProvider.
+
+Do not show or store a client secret: single page applications are public clients that use PKCE.`,
+	},
+	{
+		Name:        "protect_backend_api",
+		Description: "Protect a backend API with scopes and authorize an application to call it",
+		Arguments: []Argument{
+			{Name: "api_name", Description: "Display name of the API", Required: true},
+			{Name: "api_identifier", Description: "Identifier (audience) of the API, usually its base URL", Required: true},
+			{Name: "scopes", Description: "Comma-separated scopes of the API, e.g. orders:read,orders:write", Required: true},
+			{Name: "app_name", Description: "Name of the application that calls the API"},
+		},
+		Text: `Protect the backend API "{{.api_name}}" ({{.api_identifier}}) with scopes in {{.product}}.
+
+1. Check with get_api_resource_by_identifier whether an API resource with identifier "{{.api_identifier}}" already exists. If it does, reuse it and tell me which of the scopes {{.scopes}} are missing.
+2. Otherwise call create_api_resource with name "{{.api_name}}", identifier "{{.api_identifier}}", requiresAuthorization true and one scope per entry of "{{.scopes}}", each with a readable displayName and description.
+{{- if .app_name}}
+3. Find the application "{{.app_name}}" with get_application_by_name and call authorize_api with its ID as appId, the API resource ID as id, policyIdentifier "RBAC" and the scopes {{.scopes}}.
+4. Confirm the authorization with list_authorized_api.
+{{- else}}
+3. Ask me which application should call the API, then authorize it with authorize_api using policyIdentifier "RBAC" and the scopes {{.scopes}}, and confirm with list_authorized_api.
+{{- end}}
+
+Finally, explain how the API should validate the access tokens: the issuer, the JWKS URL, the audience "{{.api_identifier}}" and the scope checks per endpoint.`,
+	},
+	{
+		Name:        "add_mfa",
+		Description: "Add multi-factor authentication to an existing application",
+		Arguments: []Argument{
+			{Name: "app_name", Description: "Name of the application", Required: true},
+			{Name: "second_factor", Description: "Second factor to add, e.g. Email OTP, TOTP, SMS OTP or Passkey", Default: "TOTP"},
+			{Name: "first_factor", Description: "First factor of the login flow", Default: "Username and password"},
+		},
+		Text: `Add multi-factor authentication to the application "{{.app_name}}" in {{.product}}.
+
+1. Find the application with get_application_by_name and note its ID. Stop and tell me if it does not exist.
+2. Call update_login_flow with app_id set to the application ID and user_prompt "{{.first_factor}} as the first factor and {{.second_factor}} as the second factor".
+3. Summarize the resulting login flow and anything the users need to do, such as enrolling a {{.second_factor}} authenticator on their next sign-in.`,
+	},
+	{
+		Name:        "onboard_b2b_partner",
+		Description: "Onboard a B2B partner with its own application, API access and administrator",
+		Arguments: []Argument{
+			{Name: "partner_name", Description: "Name of the partner organization", Required: true},
+			{Name: "admin_email", Description: "Email address of the partner's administrator", Required: true},
+			{Name: "api_identifier", Description: "Identifier of the API the partner integrates with"},
+			{Name: "scopes", Description: "Comma-separated scopes granted to the partner"},
+		},
+		Text: `Onboard the B2B partner "{{.partner_name}}" in {{.product}}.
+
+1. Call create_m2m_app with application_name "{{.partner_name}} Integration" so that the partner's backend can obtain tokens with the client credentials grant.
+{{- if .api_identifier}}
+2. Find the API resource "{{.api_identifier}}" with get_api_resource_by_identifier and call authorize_api for the new application with policyIdentifier "RBAC" and the scopes {{if .scopes}}{{.scopes}}{{else}}I confirm after you list the available ones{{end}}.
+{{- else}}
+2. Ask me which API resources and scopes the partner may access, then grant them to the new application with authorize_api.
+{{- end}}
+3. Create the partner's administrator with create_user, using "{{.admin_email}}" as the username and email. Ask me for the first and last name instead of guessing them, and do not invent a password: let me provide it or ask the administrator to set it through the account recovery flow.
+4. Summarize what was created: the application ID and client ID (share the client secret only through a secure channel), the authorized scopes and the administrator account.`,
+	},
+}
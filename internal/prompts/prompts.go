@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Template is a parameterized prompt that walks the assistant through a workflow.
+// The text is a Go template; each argument is available as {{.<name>}} and the
+// name of the product as {{.product}}.
+type Template struct {
+	Name        string
+	Description string
+	Arguments   []Argument
+	Text        string
+}
+
+// Argument is a parameter of a prompt template.
+type Argument struct {
+	Name        string
+	Description string
+	Required    bool
+	Default     string
+}
+
+// Templates returns the built-in prompt templates followed by the ones defined in the
+// configuration file. A template from the file replaces the built-in one of the same name.
+func Templates() []Template {
+	custom := config.LoadPromptTemplates()
+	overridden := map[string]bool{}
+	for _, prompt := range custom {
+		overridden[prompt.Name] = true
+	}
+
+	templates := []Template{}
+	for _, prompt := range builtinTemplates {
+		if !overridden[prompt.Name] {
+			templates = append(templates, prompt)
+		}
+	}
+	for _, prompt := range custom {
+		template := Template{Name: prompt.Name, Description: prompt.Description, Text: prompt.Template}
+		for _, argument := range prompt.Arguments {
+			template.Arguments = append(template.Arguments, Argument{
+				Name:        argument.Name,
+				Description: argument.Description,
+				Required:    argument.Required,
+				Default:     argument.Default,
+			})
+		}
+		templates = append(templates, template)
+	}
+	return templates
+}
+
+// GetPrompt returns the MCP prompt and the handler that renders the template.
+func (t Template) GetPrompt() (mcp.Prompt, server.PromptHandlerFunc, error) {
+	parsed, err := template.New(t.Name).Option("missingkey=zero").Parse(t.Text)
+	if err != nil {
+		return mcp.Prompt{}, nil, fmt.Errorf("invalid template of prompt %q: %w", t.Name, err)
+	}
+
+	opts := []mcp.PromptOption{mcp.WithPromptDescription(t.Description)}
+	for _, argument := range t.Arguments {
+		argumentOpts := []mcp.ArgumentOption{mcp.ArgumentDescription(argument.Description)}
+		if argument.Required {
+			argumentOpts = append(argumentOpts, mcp.RequiredArgument())
+		}
+		opts = append(opts, mcp.WithArgument(argument.Name, argumentOpts...))
+	}
+	prompt := mcp.NewPrompt(t.Name, opts...)
+
+	promptImpl := func(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		data := map[string]string{"product": config.GetProductName()}
+		missing := []string{}
+		for _, argument := range t.Arguments {
+			value := strings.TrimSpace(req.Params.Arguments[argument.Name])
+			if value == "" {
+				value = argument.Default
+			}
+			if value == "" && argument.Required {
+				missing = append(missing, argument.Name)
+			}
+			data[argument.Name] = value
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("missing required arguments of prompt %q: %s", t.Name, strings.Join(missing, ", "))
+		}
+
+		var text strings.Builder
+		if err := parsed.Execute(&text, data); err != nil {
+			return nil, fmt.Errorf("failed to render prompt %q: %w", t.Name, err)
+		}
+		return mcp.NewGetPromptResult(t.Description, []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(strings.TrimSpace(text.String()))),
+		}), nil
+	}
+
+	return prompt, promptImpl, nil
+}
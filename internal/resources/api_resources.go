@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/asgardeo/go/pkg/api_resource"
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// apiResourcePageSize is the number of API resources requested per page when listing all of them.
+const apiResourcePageSize = 100
+
+func GetAPIResourceListResource() (mcp.Resource, server.ResourceHandlerFunc) {
+	productName := config.GetProductName()
+
+	apiResourceListResource := mcp.NewResource(URIScheme+"api-resources", "api-resources",
+		mcp.WithResourceDescription(fmt.Sprintf("All API resources registered in %s with their IDs, names and identifiers", productName)),
+		mcp.WithMIMEType(jsonMIMEType),
+	)
+
+	apiResourceListResourceImpl := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return nil, err
+		}
+
+		apiResources := []interface{}{}
+		limit := apiResourcePageSize
+		params := api_resource.APIResourceListParamsModel{Limit: &limit}
+		for {
+			resp, err := client.APIResource.List(ctx, &params)
+			if err != nil {
+				log.Printf("Error listing api resources: %v", err)
+				return nil, err
+			}
+			if resp.APIResources != nil {
+				for _, apiResource := range *resp.APIResources {
+					apiResources = append(apiResources, map[string]interface{}{
+						"id":         apiResource.Id,
+						"name":       apiResource.Name,
+						"identifier": apiResource.Identifier,
+						"uri":        URIScheme + "api-resources/" + apiResource.Id,
+					})
+				}
+			}
+			after, _ := asgardeo.APIResourceCursors(resp)
+			if after == "" {
+				break
+			}
+			params.After = &after
+		}
+
+		return jsonContents(req.Params.URI, apiResources)
+	}
+
+	return apiResourceListResource, apiResourceListResourceImpl
+}
+
+func GetAPIResourceResourceTemplate() (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	productName := config.GetProductName()
+
+	apiResourceTemplate := mcp.NewResourceTemplate(URIScheme+"api-resources/{id}", "api-resource",
+		mcp.WithTemplateDescription(fmt.Sprintf("An API resource registered in %s with its scopes, identified by its ID", productName)),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+
+	apiResourceTemplateImpl := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return nil, err
+		}
+
+		apiResource, err := client.APIResource.Get(ctx, templateArgument(req, "id"))
+		if err != nil {
+			log.Printf("Error retrieving api resource: %v", err)
+			return nil, err
+		}
+
+		return jsonContents(req.Params.URI, apiResource)
+	}
+
+	return apiResourceTemplate, apiResourceTemplateImpl
+}
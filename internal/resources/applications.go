@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// applicationPageSize is the number of applications requested per page when listing all of them.
+const applicationPageSize = 100
+
+func GetApplicationListResource() (mcp.Resource, server.ResourceHandlerFunc) {
+	productName := config.GetProductName()
+
+	applicationListResource := mcp.NewResource(URIScheme+"applications", "applications",
+		mcp.WithResourceDescription(fmt.Sprintf("All applications in %s with their IDs, names and client IDs", productName)),
+		mcp.WithMIMEType(jsonMIMEType),
+	)
+
+	applicationListResourceImpl := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return nil, err
+		}
+
+		apps := []interface{}{}
+		for offset := 0; ; offset += applicationPageSize {
+			resp, err := client.Application.List(ctx, applicationPageSize, offset)
+			if err != nil {
+				log.Printf("Error listing applications: %v", err)
+				return nil, err
+			}
+			if resp.Applications == nil || len(*resp.Applications) == 0 {
+				break
+			}
+			for _, app := range *resp.Applications {
+				appMap := map[string]interface{}{
+					"id":   app.Id,
+					"name": app.Name,
+					"uri":  URIScheme + "applications/" + *app.Id,
+				}
+				if app.ClientId != nil {
+					appMap["client_id"] = *app.ClientId
+				}
+				apps = append(apps, appMap)
+			}
+			if resp.TotalResults != nil && offset+applicationPageSize >= *resp.TotalResults {
+				break
+			}
+		}
+
+		return jsonContents(req.Params.URI, apps)
+	}
+
+	return applicationListResource, applicationListResourceImpl
+}
+
+func GetApplicationResourceTemplate() (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	productName := config.GetProductName()
+
+	applicationTemplate := mcp.NewResourceTemplate(URIScheme+"applications/{id}", "application",
+		mcp.WithTemplateDescription(fmt.Sprintf("An application in %s, identified by its ID", productName)),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+
+	applicationTemplateImpl := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return nil, err
+		}
+
+		id := templateArgument(req, "id")
+		app, err := asgardeo.GetApplication(ctx, client, id)
+		if err != nil {
+			log.Printf("Error retrieving application: %v", err)
+			return nil, err
+		}
+
+		return jsonContents(req.Params.URI, app)
+	}
+
+	return applicationTemplate, applicationTemplateImpl
+}
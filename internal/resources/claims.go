@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/asgardeo/go/pkg/claim"
+	"github.com/asgardeo/go/pkg/sdk"
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// localDialectID is the ID of the dialect of the local (user store) claims.
+const localDialectID = "local"
+
+func GetClaimDialectListResource() (mcp.Resource, server.ResourceHandlerFunc) {
+	productName := config.GetProductName()
+
+	claimDialectListResource := mcp.NewResource(URIScheme+"claims", "claim-dialects",
+		mcp.WithResourceDescription(fmt.Sprintf("Claim dialects in %s. Read asgardeo://claims/{dialect} for the claims of a dialect; the local claims are in asgardeo://claims/local", productName)),
+		mcp.WithMIMEType(jsonMIMEType),
+	)
+
+	claimDialectListResourceImpl := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return nil, err
+		}
+
+		var dialects []struct {
+			ID         string `json:"id"`
+			DialectURI string `json:"dialectURI"`
+		}
+		if err := asgardeo.CallManagementAPI(ctx, client, "GET", "/claim-dialects", nil, &dialects); err != nil {
+			log.Printf("Error listing claim dialects: %v", err)
+			return nil, err
+		}
+
+		dialectList := []interface{}{}
+		for _, dialect := range dialects {
+			dialectList = append(dialectList, map[string]interface{}{
+				"id":          dialect.ID,
+				"dialect_uri": dialect.DialectURI,
+				"uri":         URIScheme + "claims/" + dialect.ID,
+			})
+		}
+		return jsonContents(req.Params.URI, dialectList)
+	}
+
+	return claimDialectListResource, claimDialectListResourceImpl
+}
+
+func GetClaimListResourceTemplate() (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	productName := config.GetProductName()
+
+	claimListTemplate := mcp.NewResourceTemplate(URIScheme+"claims/{dialect}", "claims",
+		mcp.WithTemplateDescription(fmt.Sprintf("Claims of a claim dialect in %s, identified by the dialect ID (\"local\" for the local claims)", productName)),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+
+	claimListTemplateImpl := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return nil, err
+		}
+
+		claims, err := listClaims(ctx, client, templateArgument(req, "dialect"))
+		if err != nil {
+			return nil, err
+		}
+		return jsonContents(req.Params.URI, claims)
+	}
+
+	return claimListTemplate, claimListTemplateImpl
+}
+
+func GetClaimResourceTemplate() (mcp.ResourceTemplate, server.ResourceTemplateHandlerFunc) {
+	productName := config.GetProductName()
+
+	claimTemplate := mcp.NewResourceTemplate(URIScheme+"claims/{dialect}/{id}", "claim",
+		mcp.WithTemplateDescription(fmt.Sprintf("A claim in %s, identified by its dialect ID (\"local\" for the local claims) and claim ID", productName)),
+		mcp.WithTemplateMIMEType(jsonMIMEType),
+	)
+
+	claimTemplateImpl := func(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return nil, err
+		}
+
+		dialect, id := templateArgument(req, "dialect"), templateArgument(req, "id")
+		if dialect == localDialectID {
+			claims, err := listLocalClaims(ctx, client)
+			if err != nil {
+				return nil, err
+			}
+			for _, localClaim := range *claims {
+				if localClaim.Id != nil && *localClaim.Id == id {
+					return jsonContents(req.Params.URI, localClaim)
+				}
+			}
+		} else {
+			claims, err := listExternalClaims(ctx, client, dialect)
+			if err != nil {
+				return nil, err
+			}
+			for _, externalClaim := range *claims {
+				if externalClaim.Id != nil && *externalClaim.Id == id {
+					return jsonContents(req.Params.URI, externalClaim)
+				}
+			}
+		}
+		return nil, fmt.Errorf("claim %q of dialect %q: %w", id, dialect, server.ErrResourceNotFound)
+	}
+
+	return claimTemplate, claimTemplateImpl
+}
+
+// listClaims returns the claims of the dialect, using the same SDK calls as the list_claims tool.
+func listClaims(ctx context.Context, client *sdk.Client, dialect string) (interface{}, error) {
+	if dialect == localDialectID {
+		return listLocalClaims(ctx, client)
+	}
+	return listExternalClaims(ctx, client, dialect)
+}
+
+func listLocalClaims(ctx context.Context, client *sdk.Client) (*[]claim.LocalClaimResponseModel, error) {
+	excludeHiddenClaims := true
+	claims, err := client.Claim.ListLocalClaims(ctx, &claim.LocalClaimListParamsModel{
+		ExcludeHiddenClaims: &excludeHiddenClaims,
+	})
+	if err != nil {
+		log.Printf("Error listing claims: %v", err)
+		return nil, err
+	}
+	return claims, nil
+}
+
+func listExternalClaims(ctx context.Context, client *sdk.Client, dialect string) (*[]claim.ExternalClaimResponseModel, error) {
+	claims, err := client.Claim.ListExternalClaims(ctx, dialect, &claim.ExternalClaimListParamsModel{})
+	if err != nil {
+		log.Printf("Error listing claims of dialect %s: %v", dialect, err)
+		return nil, err
+	}
+	return claims, nil
+}
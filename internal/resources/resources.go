@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package resources
+
+import (
+	"strings"
+
+	"github.com/asgardeo/mcp/internal/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// URIScheme is the scheme of the URIs of the resources exposed by the server.
+const URIScheme = "asgardeo://"
+
+const jsonMIMEType = "application/json"
+
+// uriDepths is the number of path segments of the URIs of each kind of resource, from its list
+// to its objects: asgardeo://claims, asgardeo://claims/{dialect} and asgardeo://claims/{dialect}/{id}.
+var uriDepths = map[string]int{
+	"applications":  2,
+	"api-resources": 2,
+	"claims":        3,
+}
+
+// IsResourceURI reports whether the URI names a resource or a resource template of the server.
+func IsResourceURI(uri string) bool {
+	path, ok := strings.CutPrefix(uri, URIScheme)
+	if !ok {
+		return false
+	}
+	segments := strings.Split(path, "/")
+	if len(segments) > uriDepths[segments[0]] {
+		return false
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonContents returns the resource contents holding the JSON encoding of the value.
+func jsonContents(uri string, value interface{}) ([]mcp.ResourceContents, error) {
+	jsonData, err := utils.MarshalResponse(value)
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      uri,
+			MIMEType: jsonMIMEType,
+			Text:     jsonData,
+		},
+	}, nil
+}
+
+// templateArgument returns the value of a variable matched in the URI template.
+func templateArgument(req mcp.ReadResourceRequest, name string) string {
+	switch value := req.Params.Arguments[name].(type) {
+	case []string:
+		if len(value) > 0 {
+			return value[0]
+		}
+	case string:
+		return value
+	}
+	return ""
+}
@@ -0,0 +1,340 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// SAML 2.0 protocol bindings.
+const (
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingHTTPArtifact = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Artifact"
+	BindingSOAP         = "urn:oasis:names:tc:SAML:2.0:bindings:SOAP"
+)
+
+// maxMetadataSize is the largest metadata document that is read.
+const maxMetadataSize = 1 << 20
+
+// ErrNoDescriptor is returned when a metadata document lacks the role descriptor that is read.
+var ErrNoDescriptor = errors.New("metadata has no descriptor of the expected role")
+
+// Endpoint is a service endpoint of a SAML entity.
+type Endpoint struct {
+	Binding          string `json:"binding"`
+	Location         string `json:"location"`
+	ResponseLocation string `json:"response_location,omitempty"`
+	Index            int    `json:"index,omitempty"`
+	IsDefault        bool   `json:"is_default,omitempty"`
+}
+
+// SPMetadata is the content of the metadata of a service provider.
+type SPMetadata struct {
+	EntityID                  string     `json:"entity_id"`
+	AssertionConsumerServices []Endpoint `json:"assertion_consumer_services"`
+	SingleLogoutServices      []Endpoint `json:"single_logout_services,omitempty"`
+	NameIDFormats             []string   `json:"name_id_formats,omitempty"`
+	AuthnRequestsSigned       bool       `json:"authn_requests_signed"`
+	WantAssertionsSigned      bool       `json:"want_assertions_signed"`
+	// SigningCertificates and EncryptionCertificates are PEM encoded. A key descriptor without a
+	// use is listed in both.
+	SigningCertificates    []string `json:"signing_certificates,omitempty"`
+	EncryptionCertificates []string `json:"encryption_certificates,omitempty"`
+}
+
+// IdPMetadata is the content of the metadata of an identity provider.
+type IdPMetadata struct {
+	EntityID             string     `json:"entity_id"`
+	SingleSignOnServices []Endpoint `json:"single_sign_on_services"`
+	SingleLogoutServices []Endpoint `json:"single_logout_services,omitempty"`
+	NameIDFormats        []string   `json:"name_id_formats,omitempty"`
+	SigningCertificates  []string   `json:"signing_certificates,omitempty"`
+}
+
+// DefaultAssertionConsumerService returns the location of the default assertion consumer service:
+// the one marked as default, otherwise the one with the lowest index.
+func (m *SPMetadata) DefaultAssertionConsumerService() string {
+	if len(m.AssertionConsumerServices) == 0 {
+		return ""
+	}
+	for _, acs := range m.AssertionConsumerServices {
+		if acs.IsDefault {
+			return acs.Location
+		}
+	}
+	services := append([]Endpoint(nil), m.AssertionConsumerServices...)
+	sort.SliceStable(services, func(i, j int) bool { return services[i].Index < services[j].Index })
+	return services[0].Location
+}
+
+// AssertionConsumerServiceURLs returns the distinct locations of the assertion consumer services.
+func (m *SPMetadata) AssertionConsumerServiceURLs() []string {
+	return locations(m.AssertionConsumerServices)
+}
+
+type entitiesDescriptor struct {
+	XMLName  xml.Name
+	Entities []entityDescriptor   `xml:"EntityDescriptor"`
+	Nested   []entitiesDescriptor `xml:"EntitiesDescriptor"`
+}
+
+type entityDescriptor struct {
+	XMLName  xml.Name
+	EntityID string           `xml:"entityID,attr"`
+	SP       []roleDescriptor `xml:"SPSSODescriptor"`
+	IdP      []roleDescriptor `xml:"IDPSSODescriptor"`
+}
+
+type roleDescriptor struct {
+	AuthnRequestsSigned  string          `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned string          `xml:"WantAssertionsSigned,attr"`
+	KeyDescriptors       []keyDescriptor `xml:"KeyDescriptor"`
+	SingleLogoutServices []endpoint      `xml:"SingleLogoutService"`
+	NameIDFormats        []string        `xml:"NameIDFormat"`
+	ACS                  []endpoint      `xml:"AssertionConsumerService"`
+	SSO                  []endpoint      `xml:"SingleSignOnService"`
+}
+
+type keyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+type endpoint struct {
+	Binding          string `xml:"Binding,attr"`
+	Location         string `xml:"Location,attr"`
+	ResponseLocation string `xml:"ResponseLocation,attr"`
+	Index            *int   `xml:"index,attr"`
+	IsDefault        string `xml:"isDefault,attr"`
+}
+
+// ParseSPMetadata reads the metadata of a service provider. The document is an EntityDescriptor, or
+// an EntitiesDescriptor with exactly one entity that has an SPSSODescriptor.
+func ParseSPMetadata(data []byte) (*SPMetadata, error) {
+	entity, role, err := findEntity(data, func(e entityDescriptor) []roleDescriptor { return e.SP }, "SPSSODescriptor")
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &SPMetadata{
+		EntityID:             entity.EntityID,
+		SingleLogoutServices: endpoints(role.SingleLogoutServices),
+		NameIDFormats:        trimAll(role.NameIDFormats),
+		AuthnRequestsSigned:  parseBool(role.AuthnRequestsSigned),
+		WantAssertionsSigned: parseBool(role.WantAssertionsSigned),
+	}
+	for i, acs := range role.ACS {
+		if strings.TrimSpace(acs.Location) == "" {
+			return nil, fmt.Errorf("assertion consumer service %d has no location", i+1)
+		}
+	}
+	metadata.AssertionConsumerServices = endpoints(role.ACS)
+	if len(metadata.AssertionConsumerServices) == 0 {
+		return nil, fmt.Errorf("SPSSODescriptor of %q has no AssertionConsumerService", entity.EntityID)
+	}
+	metadata.SigningCertificates, metadata.EncryptionCertificates, err = certificates(role.KeyDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// ParseIdPMetadata reads the metadata of an identity provider. The document is an EntityDescriptor,
+// or an EntitiesDescriptor with exactly one entity that has an IDPSSODescriptor.
+func ParseIdPMetadata(data []byte) (*IdPMetadata, error) {
+	entity, role, err := findEntity(data, func(e entityDescriptor) []roleDescriptor { return e.IdP }, "IDPSSODescriptor")
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &IdPMetadata{
+		EntityID:             entity.EntityID,
+		SingleSignOnServices: endpoints(role.SSO),
+		SingleLogoutServices: endpoints(role.SingleLogoutServices),
+		NameIDFormats:        trimAll(role.NameIDFormats),
+	}
+	metadata.SigningCertificates, _, err = certificates(role.KeyDescriptors)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// Fetch downloads a metadata document.
+func Fetch(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata URL: %w", err)
+	}
+	req.Header.Set("Accept", "application/samlmetadata+xml, application/xml, text/xml")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s failed: status %d", url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	if len(data) > maxMetadataSize {
+		return nil, fmt.Errorf("metadata at %s is larger than %d bytes", url, maxMetadataSize)
+	}
+	return data, nil
+}
+
+// findEntity returns the only entity of the document that has a descriptor of the role, and that descriptor.
+func findEntity(data []byte, roles func(entityDescriptor) []roleDescriptor, roleName string) (*entityDescriptor, *roleDescriptor, error) {
+	var root entitiesDescriptor
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&root); err != nil {
+		return nil, nil, fmt.Errorf("invalid metadata XML: %w", err)
+	}
+
+	var entities []entityDescriptor
+	switch root.XMLName.Local {
+	case "EntityDescriptor":
+		var entity entityDescriptor
+		if err := xml.Unmarshal(data, &entity); err != nil {
+			return nil, nil, fmt.Errorf("invalid metadata XML: %w", err)
+		}
+		entities = []entityDescriptor{entity}
+	case "EntitiesDescriptor":
+		entities = flatten(root)
+	default:
+		return nil, nil, fmt.Errorf("invalid metadata: unexpected root element %s, expected EntityDescriptor or EntitiesDescriptor", root.XMLName.Local)
+	}
+
+	var matches []entityDescriptor
+	for _, entity := range entities {
+		if len(roles(entity)) > 0 {
+			matches = append(matches, entity)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		return nil, nil, fmt.Errorf("%w: no %s found", ErrNoDescriptor, roleName)
+	case len(matches) > 1:
+		return nil, nil, fmt.Errorf("metadata describes %d entities with a %s; provide the metadata of a single entity", len(matches), roleName)
+	}
+	entity := matches[0]
+	if strings.TrimSpace(entity.EntityID) == "" {
+		return nil, nil, fmt.Errorf("invalid metadata: the EntityDescriptor has no entityID")
+	}
+	entity.EntityID = strings.TrimSpace(entity.EntityID)
+	return &entity, &roles(entity)[0], nil
+}
+
+func flatten(root entitiesDescriptor) []entityDescriptor {
+	entities := append([]entityDescriptor(nil), root.Entities...)
+	for _, nested := range root.Nested {
+		entities = append(entities, flatten(nested)...)
+	}
+	return entities
+}
+
+func endpoints(raw []endpoint) []Endpoint {
+	result := make([]Endpoint, 0, len(raw))
+	for _, e := range raw {
+		converted := Endpoint{
+			Binding:          strings.TrimSpace(e.Binding),
+			Location:         strings.TrimSpace(e.Location),
+			ResponseLocation: strings.TrimSpace(e.ResponseLocation),
+			IsDefault:        parseBool(e.IsDefault),
+		}
+		if e.Index != nil {
+			converted.Index = *e.Index
+		}
+		result = append(result, converted)
+	}
+	return result
+}
+
+// certificates returns the signing and the encryption certificates of the key descriptors, PEM encoded.
+func certificates(descriptors []keyDescriptor) (signing, encryption []string, err error) {
+	for _, descriptor := range descriptors {
+		for _, raw := range descriptor.Certificates {
+			certificate, err := toPEM(raw)
+			if err != nil {
+				return nil, nil, err
+			}
+			switch strings.TrimSpace(descriptor.Use) {
+			case "signing":
+				signing = append(signing, certificate)
+			case "encryption":
+				encryption = append(encryption, certificate)
+			default:
+				signing = append(signing, certificate)
+				encryption = append(encryption, certificate)
+			}
+		}
+	}
+	return signing, encryption, nil
+}
+
+// toPEM converts the base64 content of an X509Certificate element to a PEM certificate.
+func toPEM(raw string) (string, error) {
+	compact := strings.Join(strings.Fields(raw), "")
+	der, err := base64.StdEncoding.DecodeString(compact)
+	if err != nil || len(der) == 0 {
+		return "", fmt.Errorf("invalid metadata: X509Certificate is not base64 encoded")
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
+
+func locations(services []Endpoint) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, service := range services {
+		if !seen[service.Location] {
+			seen[service.Location] = true
+			result = append(result, service.Location)
+		}
+	}
+	return result
+}
+
+func trimAll(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func parseBool(value string) bool {
+	value = strings.TrimSpace(value)
+	return value == "true" || value == "1"
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return data
+}
+
+func certificateSubject(t *testing.T, certificate string) string {
+	t.Helper()
+	block, rest := pem.Decode([]byte(certificate))
+	if block == nil || len(strings.TrimSpace(string(rest))) != 0 {
+		t.Fatalf("not a single PEM block: %q", certificate)
+	}
+	parsed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("invalid certificate: %v", err)
+	}
+	return parsed.Subject.CommonName
+}
+
+func TestParseSPMetadata(t *testing.T) {
+	metadata, err := ParseSPMetadata(readTestdata(t, "sp_metadata.xml"))
+	if err != nil {
+		t.Fatalf("ParseSPMetadata() error = %v", err)
+	}
+
+	if metadata.EntityID != "https://sp.example.com/saml" {
+		t.Errorf("EntityID = %q", metadata.EntityID)
+	}
+	wantACS := []Endpoint{
+		{Binding: BindingHTTPPost, Location: "https://sp.example.com/saml/acs", Index: 1},
+		{Binding: BindingHTTPArtifact, Location: "https://sp.example.com/saml/acs/artifact", Index: 0, IsDefault: true},
+	}
+	if !reflect.DeepEqual(metadata.AssertionConsumerServices, wantACS) {
+		t.Errorf("AssertionConsumerServices = %+v, want %+v", metadata.AssertionConsumerServices, wantACS)
+	}
+	if got := metadata.DefaultAssertionConsumerService(); got != "https://sp.example.com/saml/acs/artifact" {
+		t.Errorf("DefaultAssertionConsumerService() = %q", got)
+	}
+	wantSLO := []Endpoint{{
+		Binding:          BindingHTTPRedirect,
+		Location:         "https://sp.example.com/saml/slo",
+		ResponseLocation: "https://sp.example.com/saml/slo/response",
+	}}
+	if !reflect.DeepEqual(metadata.SingleLogoutServices, wantSLO) {
+		t.Errorf("SingleLogoutServices = %+v, want %+v", metadata.SingleLogoutServices, wantSLO)
+	}
+	wantFormats := []string{
+		"urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+		"urn:oasis:names:tc:SAML:2.0:nameid-format:persistent",
+	}
+	if !reflect.DeepEqual(metadata.NameIDFormats, wantFormats) {
+		t.Errorf("NameIDFormats = %v, want %v", metadata.NameIDFormats, wantFormats)
+	}
+	if !metadata.AuthnRequestsSigned || !metadata.WantAssertionsSigned {
+		t.Errorf("AuthnRequestsSigned = %v, WantAssertionsSigned = %v, want both true",
+			metadata.AuthnRequestsSigned, metadata.WantAssertionsSigned)
+	}
+	if len(metadata.SigningCertificates) != 1 || len(metadata.EncryptionCertificates) != 1 {
+		t.Fatalf("got %d signing and %d encryption certificates, want 1 and 1",
+			len(metadata.SigningCertificates), len(metadata.EncryptionCertificates))
+	}
+	// The signing certificate is wrapped over several lines and the encryption one is not.
+	for _, certificate := range append(metadata.SigningCertificates, metadata.EncryptionCertificates...) {
+		if cn := certificateSubject(t, certificate); cn != "sp.example.com" {
+			t.Errorf("certificate subject = %q, want sp.example.com", cn)
+		}
+	}
+}
+
+func TestParseSPMetadataFromEntities(t *testing.T) {
+	metadata, err := ParseSPMetadata(readTestdata(t, "sp_entities.xml"))
+	if err != nil {
+		t.Fatalf("ParseSPMetadata() error = %v", err)
+	}
+
+	if metadata.EntityID != "https://portal.example.com" {
+		t.Errorf("EntityID = %q, want the trimmed entityID of the service provider", metadata.EntityID)
+	}
+	if got := metadata.DefaultAssertionConsumerService(); got != "https://portal.example.com/acs/1" {
+		t.Errorf("DefaultAssertionConsumerService() = %q, want the service with the lowest index", got)
+	}
+	wantURLs := []string{"https://portal.example.com/acs/2", "https://portal.example.com/acs/1"}
+	if got := metadata.AssertionConsumerServiceURLs(); !reflect.DeepEqual(got, wantURLs) {
+		t.Errorf("AssertionConsumerServiceURLs() = %v, want %v", got, wantURLs)
+	}
+	if metadata.AuthnRequestsSigned || metadata.WantAssertionsSigned {
+		t.Errorf("signing flags are set although the metadata omits them")
+	}
+	// A key descriptor without a use applies to signing and encryption.
+	if len(metadata.SigningCertificates) != 1 || len(metadata.EncryptionCertificates) != 1 {
+		t.Errorf("got %d signing and %d encryption certificates, want 1 and 1",
+			len(metadata.SigningCertificates), len(metadata.EncryptionCertificates))
+	}
+}
+
+func TestParseSPMetadataErrors(t *testing.T) {
+	tests := []struct {
+		file    string
+		wantErr string
+		noRole  bool
+	}{
+		{file: "idp_metadata.xml", wantErr: "no SPSSODescriptor", noRole: true},
+		{file: "sp_without_acs.xml", wantErr: "no AssertionConsumerService"},
+		{file: "sp_bad_certificate.xml", wantErr: "not base64 encoded"},
+		{file: "not_metadata.xml", wantErr: "unexpected root element html"},
+		{file: "malformed.xml", wantErr: "invalid metadata XML"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			_, err := ParseSPMetadata(readTestdata(t, tt.file))
+			if err == nil {
+				t.Fatalf("ParseSPMetadata() succeeded, want an error containing %q", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseSPMetadata() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+			if got := errors.Is(err, ErrNoDescriptor); got != tt.noRole {
+				t.Errorf("errors.Is(err, ErrNoDescriptor) = %v, want %v", got, tt.noRole)
+			}
+		})
+	}
+}
+
+func TestParseIdPMetadata(t *testing.T) {
+	metadata, err := ParseIdPMetadata(readTestdata(t, "idp_metadata.xml"))
+	if err != nil {
+		t.Fatalf("ParseIdPMetadata() error = %v", err)
+	}
+
+	if metadata.EntityID != "accounts.example.com" {
+		t.Errorf("EntityID = %q", metadata.EntityID)
+	}
+	wantSSO := []Endpoint{
+		{Binding: BindingHTTPPost, Location: "https://accounts.example.com/samlsso"},
+		{Binding: BindingHTTPRedirect, Location: "https://accounts.example.com/samlsso"},
+	}
+	if !reflect.DeepEqual(metadata.SingleSignOnServices, wantSSO) {
+		t.Errorf("SingleSignOnServices = %+v, want %+v", metadata.SingleSignOnServices, wantSSO)
+	}
+	if len(metadata.SingleLogoutServices) != 1 || metadata.SingleLogoutServices[0].Binding != BindingHTTPPost {
+		t.Errorf("SingleLogoutServices = %+v", metadata.SingleLogoutServices)
+	}
+	if !reflect.DeepEqual(metadata.NameIDFormats, []string{"urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"}) {
+		t.Errorf("NameIDFormats = %v", metadata.NameIDFormats)
+	}
+	if len(metadata.SigningCertificates) != 1 {
+		t.Fatalf("got %d signing certificates, want 1", len(metadata.SigningCertificates))
+	}
+	if cn := certificateSubject(t, metadata.SigningCertificates[0]); cn != "idp.example.com" {
+		t.Errorf("certificate subject = %q, want idp.example.com", cn)
+	}
+
+	if _, err := ParseIdPMetadata(readTestdata(t, "sp_metadata.xml")); !errors.Is(err, ErrNoDescriptor) {
+		t.Errorf("ParseIdPMetadata() of SP metadata error = %v, want ErrNoDescriptor", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	metadata := readTestdata(t, "sp_metadata.xml")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metadata":
+			w.Header().Set("Content-Type", "application/samlmetadata+xml")
+			_, _ = w.Write(metadata)
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat(" ", maxMetadataSize+1)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	data, err := Fetch(context.Background(), server.Client(), server.URL+"/metadata")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if string(data) != string(metadata) {
+		t.Errorf("Fetch() returned %d bytes, want the %d bytes of the document", len(data), len(metadata))
+	}
+
+	if _, err := Fetch(context.Background(), server.Client(), server.URL+"/missing"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Fetch() of a missing document error = %v, want status 404", err)
+	}
+	if _, err := Fetch(context.Background(), server.Client(), server.URL+"/large"); err == nil || !strings.Contains(err.Error(), "larger than") {
+		t.Errorf("Fetch() of a large document error = %v, want a size error", err)
+	}
+}
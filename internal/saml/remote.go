@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+)
+
+// nonPublicPrefixes are the special purpose ranges that the netip.Addr predicates do not cover.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// PublicClient returns a copy of the HTTP client for fetching documents from locations chosen by
+// the caller of a tool, such as the metadata URL of a service provider. It only sends https
+// requests, redirects included, and only connects to public addresses, so that it cannot be used
+// to reach the network of the server. The host names, IP addresses and CIDR ranges in allowed are
+// exempt from the address check. Proxies are not used, since the check applies to the connection.
+func PublicClient(httpClient *http.Client, allowed []string) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if base, ok := httpClient.Transport.(*http.Transport); ok {
+		transport = base.Clone()
+	}
+	guard := newAddressGuard(allowed)
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+		return guard.dial(ctx, dialer, network, address)
+	}
+
+	client := *httpClient
+	client.Transport = httpsOnly{next: transport}
+	return &client
+}
+
+// httpsOnly refuses every request that is not made over https.
+type httpsOnly struct {
+	next http.RoundTripper
+}
+
+func (t httpsOnly) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme != "https" {
+		return nil, fmt.Errorf("%s is not an https URL", req.URL.Redacted())
+	}
+	return t.next.RoundTrip(req)
+}
+
+// addressGuard decides which addresses PublicClient connects to.
+type addressGuard struct {
+	hosts    map[string]bool
+	prefixes []netip.Prefix
+}
+
+func newAddressGuard(allowed []string) addressGuard {
+	guard := addressGuard{hosts: map[string]bool{}}
+	for _, entry := range allowed {
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			guard.prefixes = append(guard.prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(entry); err == nil {
+			guard.prefixes = append(guard.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		} else {
+			guard.hosts[strings.ToLower(entry)] = true
+		}
+	}
+	return guard
+}
+
+// dial connects to the address once every address its host resolves to is permitted. The checked
+// addresses are dialed, so that the host cannot resolve to another address in between.
+func (g addressGuard) dial(ctx context.Context, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if g.hosts[strings.ToLower(host)] {
+		return dialer.DialContext(ctx, network, address)
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		if g.permits(addr) {
+			continue
+		}
+		if addr.Unmap().String() == host {
+			return nil, fmt.Errorf("%s is not a public address and is not allowed", host)
+		}
+		return nil, fmt.Errorf("%s resolves to %s, which is not a public address and is not allowed", host, addr.Unmap())
+	}
+	var lastErr error
+	for _, addr := range addrs {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// permits reports whether the address is public or allowed.
+func (g addressGuard) permits(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range g.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
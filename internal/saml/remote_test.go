@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package saml
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestPublicClientRefusesNonPublicAddresses(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(readTestdata(t, "sp_metadata.xml"))
+	}))
+	defer server.Close()
+
+	if _, err := Fetch(context.Background(), PublicClient(server.Client(), nil), server.URL); err == nil || !strings.Contains(err.Error(), "not a public address") {
+		t.Errorf("Fetch() of a loopback address error = %v, want a refusal", err)
+	}
+	if _, err := Fetch(context.Background(), PublicClient(server.Client(), []string{"127.0.0.0/8"}), server.URL); err != nil {
+		t.Errorf("Fetch() of an allowed range error = %v", err)
+	}
+	if _, err := Fetch(context.Background(), PublicClient(server.Client(), []string{"127.0.0.1"}), server.URL); err != nil {
+		t.Errorf("Fetch() of an allowed address error = %v", err)
+	}
+}
+
+func TestPublicClientRequiresHTTPS(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := Fetch(context.Background(), PublicClient(server.Client(), []string{"127.0.0.1"}), server.URL); err == nil || !strings.Contains(err.Error(), "not an https URL") {
+		t.Errorf("Fetch() over http error = %v, want a refusal", err)
+	}
+}
+
+func TestPublicClientChecksRedirects(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(readTestdata(t, "sp_metadata.xml"))
+	}))
+	defer target.Close()
+	redirects := map[string]string{
+		"/http":     target.URL,
+		"/loopback": "https://[::1]:1/metadata",
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, redirects[r.URL.Path], http.StatusFound)
+	}))
+	defer server.Close()
+	client := PublicClient(server.Client(), []string{"127.0.0.1"})
+
+	if _, err := Fetch(context.Background(), client, server.URL+"/http"); err == nil || !strings.Contains(err.Error(), "not an https URL") {
+		t.Errorf("Fetch() redirected to http error = %v, want a refusal", err)
+	}
+	if _, err := Fetch(context.Background(), client, server.URL+"/loopback"); err == nil || !strings.Contains(err.Error(), "not a public address") {
+		t.Errorf("Fetch() redirected to a loopback address error = %v, want a refusal", err)
+	}
+}
+
+func TestAddressGuardPermits(t *testing.T) {
+	guard := newAddressGuard([]string{"10.1.0.0/16", "fd00::1"})
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"10.1.2.3", true},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", true},
+		{"fd00::2", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.1.0.1", true},
+	}
+	for _, tt := range tests {
+		if got := guard.permits(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("permits(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -33,61 +34,106 @@ import (
 
 func GetListAPIResourcesTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	apiResourceListTool := mcp.NewTool("list_api_resources",
-		mcp.WithDescription(fmt.Sprintf("List API Resources registered in %s", productName)),
+		mcp.WithDescription(fmt.Sprintf("List API Resources registered in %s. Returns one page with the total count and the cursors of the next and previous pages; use all=true to read them all.", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget("api_resources"),
 		mcp.WithString("filter",
 			mcp.Description(`Filter expression to apply, e.g., name eq Payments API, identifier eq payments_api. Supports 'sw', 'co', 'ew' and 'eq' operations.`),
 		),
 		mcp.WithString("before",
-			mcp.Description(`Base64 encoded cursor value for backward pagination.`),
+			mcp.Description(`Cursor for backward pagination: the previous_cursor of a previous result.`),
 		),
 		mcp.WithString("after",
-			mcp.Description(`Base64 encoded cursor value for forward pagination.`),
+			mcp.Description(`Cursor for forward pagination: the next_cursor of a previous result.`),
 		),
 		mcp.WithNumber("limit",
 			mcp.Description(`The maximum number of results to return. It is recommended to set this value to 100 or less.`),
+			mcp.Min(1),
+		),
+		mcp.WithBoolean("all",
+			mcp.Description(fmt.Sprintf("Follow the next cursors to read every matching API resource, starting at after when given, up to a safety cap of %d.", config.GetListAllLimit())),
+			mcp.DefaultBool(false),
 		),
 	)
 
 	apiResourceListToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		args := req.Params.Arguments
-		limit := utils.GetOptionalParam[int](args, "limit")
-		filter := utils.GetOptionalParam[string](args, "filter")
-		before := utils.GetOptionalParam[string](args, "before")
-		after := utils.GetOptionalParam[string](args, "after")
-		params := api_resource.APIResourceListParamsModel{
-			Limit:  limit,
-			Filter: filter,
-			Before: before,
-			After:  after,
+		var args struct {
+			Filter *string `json:"filter"`
+			Before *string `json:"before"`
+			After  *string `json:"after"`
+			Limit  *int    `json:"limit"`
+			All    bool    `json:"all"`
+		}
+		if err := utils.BindArguments(req, apiResourceListTool, &args); err != nil {
+			return toolError(err)
 		}
-		resp, err := client.APIResource.List(ctx, &params)
+		if args.All && args.Before != nil {
+			return toolError(&utils.ArgumentError{Fields: []utils.FieldError{{Field: "before", Message: "cannot be combined with all=true"}}})
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
 		if err != nil {
-			log.Printf("Error listing api resources: %v", err)
-			return nil, err
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		params := api_resource.APIResourceListParamsModel{
+			Limit:  args.Limit,
+			Filter: args.Filter,
+			Before: args.Before,
+			After:  args.After,
 		}
+		capLimit := config.GetListAllLimit()
 
-		api_resources := []interface{}{}
-		for _, apiResource := range *resp.APIResources {
-			apiResourceMap := map[string]interface{}{
-				"id":   apiResource.Id,
-				"name": apiResource.Name,
+		response := map[string]interface{}{}
+		apiResources := []apiResourceSummary{}
+		previous := ""
+		for page := 0; ; page++ {
+			if args.All {
+				// Never read past the cap, so that next_cursor continues right after the last item returned.
+				pageSize := min(config.LIST_ALL_PAGE_SIZE, capLimit-len(apiResources))
+				params.Limit = &pageSize
+			}
+			resp, err := client.APIResource.List(ctx, &params)
+			if err != nil {
+				log.Printf("Error listing api resources: %v", err)
+				return toolError(err)
+			}
+			if resp.APIResources != nil {
+				for _, apiResource := range *resp.APIResources {
+					apiResources = append(apiResources, summarizeAPIResource(apiResource))
+				}
 			}
-			if apiResource.Type != nil {
-				apiResourceMap["type"] = *apiResource.Type
+			if resp.TotalResults != nil {
+				response["total_results"] = *resp.TotalResults
 			}
-			if apiResource.RequiresAuthorization != nil {
-				apiResourceMap["requiresAuthorization"] = *apiResource.RequiresAuthorization
+
+			next, prev := asgardeo.APIResourceCursors(resp)
+			if page == 0 {
+				previous = prev
+			}
+			if !args.All || next == "" || len(apiResources) >= capLimit {
+				if next != "" {
+					response["next_cursor"] = next
+				}
+				break
 			}
-			api_resources = append(api_resources, apiResourceMap)
+			params.After = &next
+		}
+		if args.All && response["next_cursor"] != nil {
+			response["capped"] = true
+			response["note"] = fmt.Sprintf("Only the first %d API resources were read. Narrow the filter, or continue from next_cursor.", len(apiResources))
 		}
+		if previous != "" {
+			response["previous_cursor"] = previous
+		}
+		response["api_resources"] = apiResources
+		response["count"] = len(apiResources)
 
-		return mcp.NewToolResultText(fmt.Sprintf("%+v", api_resources)), nil
+		return toolResult(ctx, response)
 	}
 
 	return apiResourceListTool, apiResourceListToolImpl
@@ -95,12 +141,11 @@ func GetListAPIResourcesTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetSearchAPIResourcesByNameTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 	apiResourceSearchByNameTool := mcp.NewTool("search_api_resources_by_name",
 		mcp.WithDescription(fmt.Sprintf("Search API Resources by name registered in %s", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget("api_resources"),
 		mcp.WithString("name",
 			mcp.Required(),
 			mcp.Description("This is the name of the API resource."),
@@ -108,39 +153,40 @@ func GetSearchAPIResourcesByNameTool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	apiResourceSearchByNameToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		name := req.Params.Arguments["name"].(string)
-		resp, err := client.APIResource.GetByName(ctx, name)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var args struct {
+			Name string `json:"name"`
+		}
+		if err := utils.BindArguments(req, apiResourceSearchByNameTool, &args); err != nil {
+			return toolError(err)
+		}
+
+		resp, err := client.APIResource.GetByName(ctx, args.Name)
 		if err != nil {
 			log.Printf("Error getting api resource list by name: %v", err)
-			return nil, err
+			return toolError(err)
 		}
-		api_resources := []interface{}{}
+		apiResources := []apiResourceSummary{}
 		for _, apiResource := range *resp {
-			apiResourceMap := map[string]interface{}{
-				"id":   apiResource.Id,
-				"name": apiResource.Name,
-			}
-			if apiResource.Type != nil {
-				apiResourceMap["type"] = *apiResource.Type
-			}
-			if apiResource.RequiresAuthorization != nil {
-				apiResourceMap["requiresAuthorization"] = *apiResource.RequiresAuthorization
-			}
-			api_resources = append(api_resources, apiResourceMap)
+			apiResources = append(apiResources, summarizeAPIResource(apiResource))
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("%+v", api_resources)), nil
+		return toolResult(ctx, map[string]interface{}{"api_resources": apiResources})
 	}
 	return apiResourceSearchByNameTool, apiResourceSearchByNameToolImpl
 }
 
 func GetSearchAPIResourceByIdentifierTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 	apiResourceGetByIdentifierTool := mcp.NewTool("get_api_resource_by_identifier",
 		mcp.WithDescription(fmt.Sprintf("Get API Resource by identifier registered in %s", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget(""),
 		mcp.WithString("identifier",
 			mcp.Required(),
 			mcp.Description("This is the identifier of the API resource."),
@@ -148,36 +194,47 @@ func GetSearchAPIResourceByIdentifierTool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	apiResourceGetByIdentifierToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		identifier := req.Params.Arguments["identifier"].(string)
-		resp, err := client.APIResource.GetByIdentifier(ctx, identifier)
+		client, err := asgardeo.GetClientInstance(ctx)
 		if err != nil {
-			log.Printf("Error getting api resource by identifier: %v", err)
-			return nil, err
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
 		}
-		apiResourceMap := map[string]interface{}{
-			"id":   resp.Id,
-			"name": resp.Name,
+
+		var args struct {
+			Identifier string `json:"identifier"`
 		}
-		if resp.Type != nil {
-			apiResourceMap["type"] = *resp.Type
+		if err := utils.BindArguments(req, apiResourceGetByIdentifierTool, &args); err != nil {
+			return toolError(err)
 		}
-		if resp.RequiresAuthorization != nil {
-			apiResourceMap["requiresAuthorization"] = *resp.RequiresAuthorization
+
+		resp, err := client.APIResource.GetByIdentifier(ctx, args.Identifier)
+		if err != nil {
+			log.Printf("Error getting api resource by identifier: %v", err)
+			return toolError(err)
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("%+v", apiResourceMap)), nil
+		return toolResult(ctx, summarizeAPIResource(*resp))
 	}
 	return apiResourceGetByIdentifierTool, apiResourceGetByIdentifierToolImpl
 }
 
 func GetCreateAPIResourceTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
 
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
+	// A scope is either its name or an object with the name, display name and description.
+	scopeSchema := map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"type": "string"},
+			map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name":        map[string]interface{}{"type": "string"},
+					"displayName": map[string]interface{}{"type": "string"},
+					"description": map[string]interface{}{"type": "string"},
+				},
+				"required": []interface{}{"name"},
+			},
+		},
 	}
-
-	stringTypeSchema := map[string]interface{}{"type": "string"}
 	apiResourceCreateTool := mcp.NewTool("create_api_resource",
 		mcp.WithDescription(fmt.Sprintf("Create an API Resource in %s", productName)),
 
@@ -198,60 +255,117 @@ func GetCreateAPIResourceTool() (mcp.Tool, server.ToolHandlerFunc) {
 			mcp.Required(),
 			mcp.DefaultArray([]api_resource.ScopeCreateModel{}),
 			mcp.Description("This is the list of scopes for the API resource. Eg: [{\"name\": \"scope1\", \"displayName\": \"Scope 1\", \"description\": \"Description for scope 1\"}, {\"name\": \"scope2\", \"displayName\": \"Scope 2\", \"description\": \"Description for scope 2\"}]"),
-			mcp.Items(stringTypeSchema),
+			mcp.Items(scopeSchema),
 		),
 	)
 
 	apiResourceCreateToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		name := req.Params.Arguments["name"].(string)
-		identifier := req.Params.Arguments["identifier"].(string)
-		inputScopes := req.Params.Arguments["scopes"].([]interface{})
-		scopes := make([]api_resource.ScopeCreateModel, len(inputScopes))
-		for i, inputScope := range inputScopes {
-			scope := api_resource.ScopeCreateModel{}
-
-			switch scopeData := inputScope.(type) {
-			case string:
-				// Simplified form: only scope name provided.
-				scope.Name = scopeData
-
-			case map[string]interface{}:
-				// Structured form: detailed fields provided.
-				name, ok := scopeData["name"].(string)
-				if !ok {
-					return nil, fmt.Errorf("scope Name is required and must be a string at index %d", i)
-				}
-				scope.Name = name
-
-				if displayName, ok := scopeData["displayName"].(string); ok {
-					scope.DisplayName = &displayName
-				}
-
-				if description, ok := scopeData["description"].(string); ok {
-					scope.Description = &description
-				}
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
 
-			default:
-				return nil, fmt.Errorf("unexpected scope format at index %d", i)
-			}
+		var args struct {
+			Identifier            string          `json:"identifier"`
+			Name                  string          `json:"name"`
+			RequiresAuthorization bool            `json:"requiresAuthorization"`
+			Scopes                []scopeArgument `json:"scopes"`
+		}
+		if err := utils.BindArguments(req, apiResourceCreateTool, &args); err != nil {
+			return toolError(err)
+		}
 
-			scopes[i] = scope
+		scopes := make([]api_resource.ScopeCreateModel, len(args.Scopes))
+		for i, scope := range args.Scopes {
+			scopes[i] = api_resource.ScopeCreateModel(scope)
 		}
 
-		requiresAuthorization := req.Params.Arguments["requiresAuthorization"].(bool)
 		newApiResource := api_resource.APIResourceCreateModel{
-			Name:                  name,
-			Identifier:            identifier,
+			Name:                  args.Name,
+			Identifier:            args.Identifier,
 			Scopes:                &scopes,
-			RequiresAuthorization: &requiresAuthorization,
+			RequiresAuthorization: &args.RequiresAuthorization,
 		}
 
 		resp, err := client.APIResource.Create(ctx, &newApiResource)
 		if err != nil {
 			log.Printf("Error while creating API resource: %v", err)
-			return nil, err
+			return toolError(err)
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("%+v", resp)), nil
+		return toolResult(ctx, describeAPIResource(*resp))
 	}
 	return apiResourceCreateTool, apiResourceCreateToolImpl
 }
+
+// scopeArgument is a scope of the create_api_resource tool, given either as its name or as an object.
+type scopeArgument api_resource.ScopeCreateModel
+
+func (s *scopeArgument) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		// Simplified form: only scope name provided.
+		*s = scopeArgument{Name: name}
+		return nil
+	}
+	// Structured form: detailed fields provided.
+	return json.Unmarshal(data, (*api_resource.ScopeCreateModel)(s))
+}
+
+// apiResourceSummary is an API resource as listed by the tools.
+type apiResourceSummary struct {
+	ID                    string `json:"id"`
+	Name                  string `json:"name"`
+	Identifier            string `json:"identifier"`
+	Type                  string `json:"type,omitempty"`
+	RequiresAuthorization *bool  `json:"requires_authorization,omitempty"`
+}
+
+// apiResourceDetails is an API resource with its scopes.
+type apiResourceDetails struct {
+	apiResourceSummary
+	Description string         `json:"description,omitempty"`
+	Scopes      []scopeDetails `json:"scopes"`
+}
+
+type scopeDetails struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Description string `json:"description,omitempty"`
+}
+
+func summarizeAPIResource(apiResource api_resource.APIResourceListItemModel) apiResourceSummary {
+	return apiResourceSummary{
+		ID:                    apiResource.Id,
+		Name:                  apiResource.Name,
+		Identifier:            apiResource.Identifier,
+		Type:                  utils.StringValue(apiResource.Type),
+		RequiresAuthorization: apiResource.RequiresAuthorization,
+	}
+}
+
+func describeAPIResource(apiResource api_resource.APIResourceInfoResponseModel) apiResourceDetails {
+	details := apiResourceDetails{
+		apiResourceSummary: apiResourceSummary{
+			ID:                    apiResource.Id,
+			Name:                  apiResource.Name,
+			Identifier:            apiResource.Identifier,
+			Type:                  utils.StringValue(apiResource.Type),
+			RequiresAuthorization: apiResource.RequiresAuthorization,
+		},
+		Description: utils.StringValue(apiResource.Description),
+		Scopes:      []scopeDetails{},
+	}
+	if apiResource.Scopes != nil {
+		for _, scope := range *apiResource.Scopes {
+			details.Scopes = append(details.Scopes, scopeDetails{
+				ID:          scope.Id,
+				Name:        scope.Name,
+				DisplayName: scope.DisplayName,
+				Description: utils.StringValue(scope.Description),
+			})
+		}
+	}
+	return details
+}
@@ -20,11 +20,17 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/asgardeo/go/pkg/application"
+	"github.com/asgardeo/go/pkg/sdk"
 	"github.com/asgardeo/mcp/internal/asgardeo"
 	"github.com/asgardeo/mcp/internal/config"
 	"github.com/asgardeo/mcp/internal/utils"
@@ -32,47 +38,207 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// applicationFilterClause matches one condition of an application filter expression.
+var applicationFilterClause = regexp.MustCompile(`^(name|clientId|issuer|templateId) (eq|sw|ew|co) \S.*$`)
+
 func GetListApplicationsTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	appListTool := mcp.NewTool("list_applications",
-		mcp.WithDescription(fmt.Sprintf("List all applications in %s", productName)),
+		mcp.WithDescription(fmt.Sprintf("List applications in %s. Returns one page and the total number of matching applications; use offset to page through them or all=true to read them all.", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of applications to return in the page."),
+			mcp.DefaultNumber(30),
+			mcp.Min(1),
+		),
+		mcp.WithNumber("offset",
+			mcp.Description("Number of applications to skip, for reading the following pages."),
+			mcp.DefaultNumber(0),
+			mcp.Min(0),
+		),
+		mcp.WithString("filter",
+			mcp.Description("SCIM style filter expression on name, clientId, issuer or templateId with the eq, sw, ew or co operators, combined with and/or. Eg: name sw Pet, clientId eq 8fx3kdQb, templateId eq single-page-application"),
+		),
+		mcp.WithBoolean("all",
+			mcp.Description(fmt.Sprintf("Read every matching application instead of one page, up to a safety cap of %d.", config.GetListAllLimit())),
+			mcp.DefaultBool(false),
+		),
+		mcp.WithString("sort_by",
+			mcp.Description("Sort the returned applications by this field. Without all=true only the returned page is sorted."),
+			mcp.Enum("name", "client_id"),
+		),
+		mcp.WithString("sort_order",
+			mcp.Description("Sort order."),
+			mcp.Enum("asc", "desc"),
+			mcp.DefaultString("asc"),
+		),
+		withResponseBudget("applications"),
 	)
 
 	appListToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		resp, err := client.Application.List(ctx, 10, 0)
-		if err != nil {
-			log.Printf("Error listing applications: %v", err)
-			return nil, err
-		}
-		apps := []interface{}{}
-		for _, app := range *resp.Applications {
-			appName := *app.Name
-			appID := *app.Id
-			apps = append(apps, map[string]interface{}{
-				"name": appName,
-				"id":   appID,
-			})
+		var args struct {
+			Limit     int    `json:"limit"`
+			Offset    int    `json:"offset"`
+			Filter    string `json:"filter"`
+			All       bool   `json:"all"`
+			SortBy    string `json:"sort_by"`
+			SortOrder string `json:"sort_order"`
+		}
+		if err := utils.BindArguments(req, appListTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := validateApplicationFilter(args.Filter); err != nil {
+			return toolError(err)
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		params := asgardeo.ApplicationListParams{Limit: args.Limit, Offset: args.Offset, Filter: args.Filter}
+		capLimit := config.GetListAllLimit()
+		if args.All {
+			params.Limit = min(config.LIST_ALL_PAGE_SIZE, capLimit)
+		}
+
+		apps := []applicationSummary{}
+		total := 0
+		for {
+			resp, err := asgardeo.ListApplications(ctx, client, params)
+			if err != nil {
+				log.Printf("Error listing applications: %v", err)
+				return toolError(err)
+			}
+			total = resp.TotalResults
+			for _, app := range resp.Applications {
+				apps = append(apps, applicationSummary{
+					ID:         app.ID,
+					Name:       app.Name,
+					ClientID:   app.ClientID,
+					TemplateID: app.TemplateID,
+				})
+			}
+			params.Offset += len(resp.Applications)
+			if !args.All || len(resp.Applications) == 0 || params.Offset >= total || len(apps) >= capLimit {
+				break
+			}
+		}
+		capped := false
+		if args.All && len(apps) >= capLimit {
+			apps = apps[:capLimit]
+			capped = args.Offset+len(apps) < total
 		}
+		sortApplications(apps, args.SortBy, args.SortOrder)
 
-		return mcp.NewToolResultText(fmt.Sprintf("%+v", apps)), nil
+		response := map[string]interface{}{
+			"applications":  apps,
+			"total_results": total,
+			"offset":        args.Offset,
+			"count":         len(apps),
+		}
+		if next := args.Offset + len(apps); next < total {
+			response["next_offset"] = next
+		}
+		if capped {
+			response["capped"] = true
+			response["note"] = fmt.Sprintf("Only the first %d of %d applications were read. Narrow the filter, or continue from next_offset.", len(apps), total)
+		}
+		return toolResult(ctx, response)
 	}
 
 	return appListTool, appListToolImpl
 }
 
-func GetCreateSinglePageAppTool() (mcp.Tool, server.ToolHandlerFunc) {
-	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
+// validateApplicationFilter checks that a filter expression only uses the attributes and operators
+// supported by the application listing, so that a mistake is reported before calling the server.
+func validateApplicationFilter(filter string) error {
+	if filter == "" {
+		return nil
+	}
+	clauses, err := filterClauses(filter)
 	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
+		return &utils.ArgumentError{Fields: []utils.FieldError{{Field: "filter", Message: err.Error()}}}
+	}
+	for _, clause := range clauses {
+		if !applicationFilterClause.MatchString(clause) {
+			return &utils.ArgumentError{Fields: []utils.FieldError{{
+				Field:   "filter",
+				Message: fmt.Sprintf("invalid condition %q: expected <name|clientId|issuer|templateId> <eq|sw|ew|co> <value>", clause),
+			}}}
+		}
+	}
+	return nil
+}
+
+// filterClauses splits a filter expression into its conditions at the "and" and "or" keywords.
+// A quoted value is kept whole, so that a keyword inside it, as in name eq "R and D", does not
+// split the condition.
+func filterClauses(filter string) ([]string, error) {
+	var clauses, words []string
+	var word strings.Builder
+	inWord, quoted, escaped := false, false, false
+	endWord := func() {
+		if !inWord {
+			return
+		}
+		if w := word.String(); (strings.EqualFold(w, "and") || strings.EqualFold(w, "or")) && len(words) > 0 {
+			clauses = append(clauses, strings.Join(words, " "))
+			words = nil
+		} else {
+			words = append(words, w)
+		}
+		word.Reset()
+		inWord = false
+	}
+	for _, r := range filter {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && unicode.IsSpace(r):
+			endWord()
+			continue
+		}
+		word.WriteRune(r)
+		inWord = true
 	}
+	if quoted {
+		return nil, errors.New("unterminated quoted value")
+	}
+	endWord()
+	if len(words) > 0 || len(clauses) > 0 {
+		clauses = append(clauses, strings.Join(words, " "))
+	}
+	return clauses, nil
+}
+
+func sortApplications(apps []applicationSummary, sortBy, sortOrder string) {
+	if sortBy == "" {
+		return
+	}
+	key := func(app applicationSummary) string {
+		if sortBy == "client_id" {
+			return app.ClientID
+		}
+		return strings.ToLower(app.Name)
+	}
+	sort.SliceStable(apps, func(i, j int) bool {
+		if sortOrder == "desc" {
+			return key(apps[i]) > key(apps[j])
+		}
+		return key(apps[i]) < key(apps[j])
+	})
+}
+
+func GetCreateSinglePageAppTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
 
 	spaTool := mcp.NewTool("create_single_page_app",
 		mcp.WithDescription(fmt.Sprintf("Create a new Single Page Application in %s", productName)),
@@ -81,16 +247,27 @@ func GetCreateSinglePageAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	spaToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appName := req.Params.Arguments["application_name"].(string)
-		redirectURL := req.Params.Arguments["redirect_url"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var args struct {
+			ApplicationName string `json:"application_name"`
+			RedirectURL     string `json:"redirect_url"`
+		}
+		if err := utils.BindArguments(req, spaTool, &args); err != nil {
+			return toolError(err)
+		}
 
-		spa, err := client.Application.CreateSinglePageApp(ctx, appName, redirectURL)
+		spa, err := client.Application.CreateSinglePageApp(ctx, args.ApplicationName, args.RedirectURL)
 		if err != nil {
 			log.Printf("Error creating SPA: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		baseURL := client.Config.BaseURL
+		endpoints := asgardeo.ResolveOIDCEndpoints(ctx, client)
 		response := map[string]interface{}{
 			"application_configurations": map[string]string{
 				"name":             spa.Name,
@@ -100,20 +277,10 @@ func GetCreateSinglePageAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 				"scope":            spa.AuthorizedScopes,
 				"application_type": string(spa.AppType),
 			},
-			"oauth_endpoints": map[string]string{
-				"base_url":      baseURL,
-				"authorize_url": fmt.Sprintf("%s/oauth2/authorize", baseURL),
-				"token_url":     fmt.Sprintf("%s/oauth2/token", baseURL),
-				"jwks_url":      fmt.Sprintf("%s/oauth2/jwks", baseURL),
-				"userinfo_url":  fmt.Sprintf("%s/oauth2/userinfo", baseURL),
-			},
+			"oauth_endpoints": endpoints,
 		}
 
-		jsonData, err := utils.MarshalResponse(response)
-		if err != nil {
-			return nil, err
-		}
-		return mcp.NewToolResultText(jsonData), nil
+		return toolResult(ctx, response)
 	}
 
 	return spaTool, spaToolImpl
@@ -121,29 +288,40 @@ func GetCreateSinglePageAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetCreateWebAppWithSSRTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	webappTool := mcp.NewTool("create_webapp_with_ssr",
 		mcp.WithDescription(fmt.Sprintf("Create a new regular web application that implements server side rendring in %s", productName)),
 		mcp.WithString("application_name", mcp.Description("Name of the application"), mcp.Required()),
 		mcp.WithString("redirect_url", mcp.Description("Redirect URL of the application"), mcp.Required()),
+		withSecretSink(),
 	)
 
 	webappToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appName := req.Params.Arguments["application_name"].(string)
-		redirectURL := req.Params.Arguments["redirect_url"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
 
-		webapp, err := client.Application.CreateWebAppWithSSR(ctx, appName, redirectURL)
+		var args struct {
+			ApplicationName string `json:"application_name"`
+			RedirectURL     string `json:"redirect_url"`
+			secretSinkArguments
+		}
+		if err := utils.BindArguments(req, webappTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := args.secretSinkArguments.check(); err != nil {
+			return toolError(err)
+		}
+
+		webapp, err := client.Application.CreateWebAppWithSSR(ctx, args.ApplicationName, args.RedirectURL)
 		if err != nil {
 			log.Printf("Error creating SPA: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		baseURL := client.Config.BaseURL
+		endpoints := asgardeo.ResolveOIDCEndpoints(ctx, client)
 		response := map[string]interface{}{
 			"application_configurations": map[string]string{
 				"name":             webapp.Name,
@@ -153,20 +331,16 @@ func GetCreateWebAppWithSSRTool() (mcp.Tool, server.ToolHandlerFunc) {
 				"scope":            webapp.AuthorizedScopes,
 				"application_type": string(webapp.AppType),
 			},
-			"oauth_endpoints": map[string]string{
-				"base_url":      baseURL,
-				"authorize_url": fmt.Sprintf("%s/oauth2/authorize", baseURL),
-				"token_url":     fmt.Sprintf("%s/oauth2/token", baseURL),
-				"jwks_url":      fmt.Sprintf("%s/oauth2/jwks", baseURL),
-				"userinfo_url":  fmt.Sprintf("%s/oauth2/userinfo", baseURL),
-			},
+			"oauth_endpoints": endpoints,
 		}
-
-		jsonData, err := utils.MarshalResponse(response)
+		secretRef, err := deliverSecret(args.secretSinkArguments, fmt.Sprintf("Client secret of application %s (client ID %s)", webapp.Name, webapp.ClientId),
+			clientSecret{BaseURL: endpoints.BaseURL, Issuer: endpoints.Issuer, ClientID: webapp.ClientId, Secret: webapp.ClientSecret})
 		if err != nil {
-			return nil, err
+			return secretNotDelivered(err, response)
 		}
-		return mcp.NewToolResultText(jsonData), nil
+		response["client_secret"] = secretRef
+
+		return toolResult(ctx, response)
 	}
 
 	return webappTool, webappToolImpl
@@ -174,11 +348,6 @@ func GetCreateWebAppWithSSRTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetCreateMobileAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	mobileAppTool := mcp.NewTool("create_mobile_app",
 		mcp.WithDescription(fmt.Sprintf("Create a new Mobile Application in %s", productName)),
@@ -187,16 +356,27 @@ func GetCreateMobileAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	mobileAppToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appName := req.Params.Arguments["application_name"].(string)
-		redirectURL := req.Params.Arguments["redirect_url"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var args struct {
+			ApplicationName string `json:"application_name"`
+			RedirectURL     string `json:"redirect_url"`
+		}
+		if err := utils.BindArguments(req, mobileAppTool, &args); err != nil {
+			return toolError(err)
+		}
 
-		mobileApp, err := client.Application.CreateMobileApp(ctx, appName, redirectURL)
+		mobileApp, err := client.Application.CreateMobileApp(ctx, args.ApplicationName, args.RedirectURL)
 		if err != nil {
 			log.Printf("Error creating mobile app: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		baseURL := client.Config.BaseURL
+		endpoints := asgardeo.ResolveOIDCEndpoints(ctx, client)
 		response := map[string]interface{}{
 			"application_configurations": map[string]string{
 				"name":             mobileApp.Name,
@@ -206,20 +386,10 @@ func GetCreateMobileAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 				"scope":            mobileApp.AuthorizedScopes,
 				"application_type": string(mobileApp.AppType),
 			},
-			"oauth_endpoints": map[string]string{
-				"base_url":      baseURL,
-				"authorize_url": fmt.Sprintf("%s/oauth2/authorize", baseURL),
-				"token_url":     fmt.Sprintf("%s/oauth2/token", baseURL),
-				"jwks_url":      fmt.Sprintf("%s/oauth2/jwks", baseURL),
-				"userinfo_url":  fmt.Sprintf("%s/oauth2/userinfo", baseURL),
-			},
+			"oauth_endpoints": endpoints,
 		}
 
-		jsonData, err := utils.MarshalResponse(response)
-		if err != nil {
-			return nil, err
-		}
-		return mcp.NewToolResultText(jsonData), nil
+		return toolResult(ctx, response)
 	}
 
 	return mobileAppTool, mobileAppToolImpl
@@ -227,27 +397,38 @@ func GetCreateMobileAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetCreateM2MAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	mobileAppTool := mcp.NewTool("create_m2m_app",
 		mcp.WithDescription(fmt.Sprintf("Create a new M2M Application in %s", productName)),
 		mcp.WithString("application_name", mcp.Description("Name of the application"), mcp.Required()),
+		withSecretSink(),
 	)
 
 	mobileAppToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appName := req.Params.Arguments["application_name"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var args struct {
+			ApplicationName string `json:"application_name"`
+			secretSinkArguments
+		}
+		if err := utils.BindArguments(req, mobileAppTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := args.secretSinkArguments.check(); err != nil {
+			return toolError(err)
+		}
 
-		m2mApp, err := client.Application.CreateM2MApp(ctx, appName)
+		m2mApp, err := client.Application.CreateM2MApp(ctx, args.ApplicationName)
 		if err != nil {
 			log.Printf("Error creating mobile app: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		baseURL := client.Config.BaseURL
+		endpoints := asgardeo.ResolveOIDCEndpoints(ctx, client)
 		response := map[string]interface{}{
 			"application_configurations": map[string]string{
 				"name":             m2mApp.Name,
@@ -255,46 +436,767 @@ func GetCreateM2MAppTool() (mcp.Tool, server.ToolHandlerFunc) {
 				"client_id":        m2mApp.ClientId,
 				"application_type": string(m2mApp.AppType),
 			},
-			"oauth_endpoints": map[string]string{
-				"token_url":    fmt.Sprintf("%s/oauth2/token", baseURL),
-				"jwks_url":     fmt.Sprintf("%s/oauth2/jwks", baseURL),
-				"userinfo_url": fmt.Sprintf("%s/oauth2/userinfo", baseURL),
-			},
+			"oauth_endpoints": endpoints,
 		}
-
-		jsonData, err := utils.MarshalResponse(response)
+		secretRef, err := deliverSecret(args.secretSinkArguments, fmt.Sprintf("Client secret of application %s (client ID %s)", m2mApp.Name, m2mApp.ClientId),
+			clientSecret{BaseURL: endpoints.BaseURL, Issuer: endpoints.Issuer, ClientID: m2mApp.ClientId, Secret: m2mApp.ClientSecret})
 		if err != nil {
-			return nil, err
+			return secretNotDelivered(err, response)
 		}
-		return mcp.NewToolResultText(jsonData), nil
+		response["client_secret"] = secretRef
+
+		return toolResult(ctx, response)
 	}
 
 	return mobileAppTool, mobileAppToolImpl
 }
 
-func GetSearchApplicationByNameTool() (mcp.Tool, server.ToolHandlerFunc) {
+func GetCreateOIDCApplicationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	oidcAppTool := mcp.NewTool("create_oidc_application",
+		mcp.WithDescription(fmt.Sprintf("Create an OAuth/OIDC application in %s with an explicit inbound configuration, for mixes the templates do not cover: "+
+			"eg: authorization code with token exchange, the device flow for a CLI, or a confidential client authenticating with private_key_jwt. "+
+			"Combinations that do not make sense, such as implicit without redirect URLs, are rejected before anything is created.", productName)),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("application_name", mcp.Description("Name of the application"), mcp.Required()),
+		mcp.WithString("description", mcp.Description("Description of the application")),
+		withOIDCSettings(),
+		mcp.WithString("token_endpoint_auth_method",
+			mcp.Description("How a confidential client authenticates at the token endpoint. Defaults to client_secret_basic; not allowed for public clients."),
+			mcp.Enum("client_secret_basic", "client_secret_post", "private_key_jwt", "tls_client_auth"),
+		),
+		mcp.WithString("token_endpoint_auth_signing_algorithm", mcp.Description("Algorithm of the private_key_jwt client assertions, eg: PS256")),
+		mcp.WithString("tls_client_auth_subject_dn", mcp.Description("Subject DN of the client certificate, for tls_client_auth")),
+		mcp.WithString("jwks_uri", mcp.Description("JWKS URL with the public keys of the application, for private_key_jwt, request object signing and ID token encryption")),
+		mcp.WithString("certificate", mcp.Description("PEM certificate of the application, as an alternative to jwks_uri")),
+		withSecretSink(),
+	)
+	// grant_types is the core of the configuration, so unlike in the update tool it is required.
+	oidcAppTool.InputSchema.Required = append(oidcAppTool.InputSchema.Required, "grant_types")
+
+	oidcAppToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			ApplicationName             string `json:"application_name"`
+			Description                 string `json:"description"`
+			TokenEndpointAuthMethod     string `json:"token_endpoint_auth_method"`
+			TokenEndpointAuthSigningAlg string `json:"token_endpoint_auth_signing_algorithm"`
+			TLSClientAuthSubjectDN      string `json:"tls_client_auth_subject_dn"`
+			JWKSURI                     string `json:"jwks_uri"`
+			Certificate                 string `json:"certificate"`
+			oidcSettings
+			secretSinkArguments
+		}
+		if err := utils.BindArguments(req, oidcAppTool, &args); err != nil {
+			return toolError(err)
+		}
+		oidcConfig := map[string]interface{}{}
+		args.oidcSettings.apply(oidcConfig)
+		problems := oidcConfigProblems(oidcConfig)
+		if len(args.GrantTypes) == 0 {
+			problems = append(problems, utils.FieldError{Field: "grant_types", Message: "at least one grant type is required"})
+		}
+
+		publicClient := args.PublicClient != nil && *args.PublicClient
+		if !publicClient {
+			if err := args.secretSinkArguments.check(); err != nil {
+				return toolError(err)
+			}
+		}
+
+		hasKeys := args.JWKSURI != "" || args.Certificate != ""
+		if args.JWKSURI != "" && args.Certificate != "" {
+			problems = append(problems, utils.FieldError{Field: "jwks_uri, certificate", Message: "give only one of them"})
+		}
+		if publicClient && args.TokenEndpointAuthMethod != "" {
+			problems = append(problems, utils.FieldError{Field: "token_endpoint_auth_method", Message: "a public client does not authenticate at the token endpoint"})
+		}
+		if args.TokenEndpointAuthMethod == "private_key_jwt" && !hasKeys {
+			problems = append(problems, utils.FieldError{Field: "jwks_uri", Message: "jwks_uri or certificate is required to verify the private_key_jwt assertions"})
+		}
+		if args.TokenEndpointAuthMethod == "tls_client_auth" && args.TLSClientAuthSubjectDN == "" {
+			problems = append(problems, utils.FieldError{Field: "tls_client_auth_subject_dn", Message: "is required for tls_client_auth"})
+		}
+		if args.TokenEndpointAuthSigningAlg != "" && args.TokenEndpointAuthMethod != "private_key_jwt" {
+			problems = append(problems, utils.FieldError{Field: "token_endpoint_auth_signing_algorithm", Message: "only applies to private_key_jwt"})
+		}
+		if args.IDTokenEncryption != nil && *args.IDTokenEncryption && !hasKeys {
+			problems = append(problems, utils.FieldError{Field: "id_token_encryption", Message: "jwks_uri or certificate is required to encrypt the ID tokens"})
+		}
+		if args.ValidateRequestObjectSignature != nil && *args.ValidateRequestObjectSignature && !hasKeys {
+			problems = append(problems, utils.FieldError{Field: "validate_request_object_signature", Message: "jwks_uri or certificate is required to validate the request object signatures"})
+		}
+		if len(problems) > 0 {
+			return toolError(&utils.ArgumentError{Fields: problems})
+		}
+
+		if args.TokenEndpointAuthMethod != "" {
+			setNested(oidcConfig, args.TokenEndpointAuthMethod, "clientAuthentication", "tokenEndpointAuthMethod")
+		}
+		if args.TokenEndpointAuthSigningAlg != "" {
+			setNested(oidcConfig, args.TokenEndpointAuthSigningAlg, "clientAuthentication", "tokenEndpointAuthSigningAlg")
+		}
+		if args.TLSClientAuthSubjectDN != "" {
+			setNested(oidcConfig, args.TLSClientAuthSubjectDN, "clientAuthentication", "tlsClientAuthSubjectDn")
+		}
+		app := map[string]interface{}{
+			"name":                         args.ApplicationName,
+			"inboundProtocolConfiguration": map[string]interface{}{"oidc": oidcConfig},
+		}
+		if args.Description != "" {
+			app["description"] = args.Description
+		}
+		if args.JWKSURI != "" {
+			app["advancedConfigurations"] = map[string]interface{}{"certificate": map[string]interface{}{"type": "JWKS", "value": args.JWKSURI}}
+		} else if args.Certificate != "" {
+			app["advancedConfigurations"] = map[string]interface{}{"certificate": map[string]interface{}{"type": "PEM", "value": args.Certificate}}
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+		appID, err := asgardeo.CreateApplication(ctx, client, app)
+		if err != nil {
+			log.Printf("Error creating OIDC application: %v", err)
+			return toolError(err)
+		}
+		created, err := asgardeo.GetInboundProtocol(ctx, client, appID, "oidc")
+		if err != nil {
+			log.Printf("Error retrieving OAuth configuration of application: %v", err)
+			return toolError(err)
+		}
+
+		endpoints := asgardeo.ResolveOIDCEndpoints(ctx, client)
+		clientID, _ := created["clientId"].(string)
+		appConfig := map[string]interface{}{
+			"name":          args.ApplicationName,
+			"id":            appID,
+			"client_id":     clientID,
+			"grant_types":   args.GrantTypes,
+			"public_client": publicClient,
+		}
+		if args.RedirectURLs != nil {
+			appConfig["redirect_urls"] = args.RedirectURLs
+		}
+		response := map[string]interface{}{
+			"application_configurations": appConfig,
+			"oauth_endpoints":            endpoints,
+		}
+		if secret, _ := created["clientSecret"].(string); secret != "" && !publicClient {
+			secretRef, err := deliverSecret(args.secretSinkArguments, fmt.Sprintf("Client secret of application %s (client ID %s)", args.ApplicationName, clientID),
+				clientSecret{BaseURL: endpoints.BaseURL, Issuer: endpoints.Issuer, ClientID: clientID, Secret: secret})
+			if err != nil {
+				return secretNotDelivered(err, response)
+			}
+			response["client_secret"] = secretRef
+		}
+
+		return toolResult(ctx, response)
+	}
+
+	return oidcAppTool, oidcAppToolImpl
+}
+
+// applicationSections are the sections of the get_application document. Each is listed with the
+// field of the application record that holds it; the others are fetched separately.
+var applicationSections = []struct {
+	name  string
+	field string
+}{
+	{"basic", ""},
+	{"inbound_protocols", ""},
+	{"claim_configuration", "claimConfiguration"},
+	{"authentication_sequence", "authenticationSequence"},
+	{"advanced_configurations", "advancedConfigurations"},
+	{"associated_roles", "associatedRoles"},
+	{"authorized_apis", ""},
+}
+
+func GetApplicationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	sectionNames := make([]string, len(applicationSections))
+	for i, section := range applicationSections {
+		sectionNames[i] = section.name
+	}
+
+	getApplicationTool := mcp.NewTool("get_application",
+		mcp.WithDescription(fmt.Sprintf("Get the full configuration of an application in %s: basic details, inbound protocol configurations (OIDC grant types, PKCE, token settings, callback URLs, SAML), claim configuration, authentication sequence, advanced configurations, associated roles and authorized APIs. Identify the application by exactly one of id, name or client_id.", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithString("id", mcp.Description("ID of the application")),
+		mcp.WithString("name", mcp.Description("Name of the application")),
+		mcp.WithString("client_id", mcp.Description("Client ID of the application")),
+		mcp.WithArray("sections",
+			mcp.Description("Sections to return. Returns every section when omitted."),
+			mcp.Items(map[string]interface{}{"type": "string", "enum": sectionNames}),
+		),
+		withResponseBudget(""),
+	)
+
+	getApplicationToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			ID       string   `json:"id"`
+			Name     string   `json:"name"`
+			ClientID string   `json:"client_id"`
+			Sections []string `json:"sections"`
+		}
+		if err := utils.BindArguments(req, getApplicationTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := requireOneOf(map[string]string{"id": args.ID, "name": args.Name, "client_id": args.ClientID}); err != nil {
+			return toolError(err)
+		}
+		selected := map[string]bool{}
+		for _, section := range args.Sections {
+			selected[section] = true
+		}
+		wants := func(section string) bool { return len(selected) == 0 || selected[section] }
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		appID := args.ID
+		if appID == "" {
+			if appID, err = asgardeo.FindApplicationID(ctx, client, args.Name, args.ClientID); err != nil {
+				log.Printf("Error finding application: %v", err)
+				return toolError(err)
+			}
+		}
+		app, err := asgardeo.GetApplication(ctx, client, appID)
+		if err != nil {
+			log.Printf("Error retrieving application: %v", err)
+			return toolError(err)
+		}
+
+		document := map[string]interface{}{
+			"id":   app["id"],
+			"name": app["name"],
+		}
+		inboundProtocols, _ := app["inboundProtocols"].([]interface{})
+		delete(app, "inboundProtocols")
+		for _, section := range applicationSections {
+			if section.field != "" {
+				if wants(section.name) {
+					document[section.name] = app[section.field]
+				}
+				delete(app, section.field)
+			}
+		}
+		if wants("basic") {
+			document["basic"] = app
+		}
+
+		if wants("inbound_protocols") {
+			protocols := map[string]interface{}{}
+			for _, inbound := range inboundProtocols {
+				protocolType, _ := inbound.(map[string]interface{})["type"].(string)
+				protocol, ok := asgardeo.InboundProtocolPaths[protocolType]
+				if !ok {
+					protocols[protocolType] = inbound
+					continue
+				}
+				protocolConfig, err := asgardeo.GetInboundProtocol(ctx, client, appID, protocol)
+				if err != nil {
+					log.Printf("Error retrieving %s configuration of application: %v", protocol, err)
+					return toolError(err)
+				}
+				// The client secret is never returned to the model.
+				delete(protocolConfig, "clientSecret")
+				protocols[protocol] = protocolConfig
+			}
+			document["inbound_protocols"] = protocols
+		}
+
+		if wants("authorized_apis") {
+			authorizedAPIs, err := asgardeo.GetAuthorizedAPIs(ctx, client, appID)
+			if err != nil {
+				log.Printf("Error listing authorized APIs: %v", err)
+				return toolError(err)
+			}
+			document["authorized_apis"] = authorizedAPIs
+		}
+
+		return toolResult(ctx, document)
+	}
+
+	return getApplicationTool, getApplicationToolImpl
+}
+
+// requireOneOf checks that exactly one of the alternative arguments is set.
+func requireOneOf(args map[string]string) error {
+	names := make([]string, 0, len(args))
+	set := 0
+	for name, value := range args {
+		names = append(names, name)
+		if value != "" {
+			set++
+		}
+	}
+	if set == 1 {
+		return nil
+	}
+	sort.Strings(names)
+	return &utils.ArgumentError{Fields: []utils.FieldError{{
+		Field:   strings.Join(names, ", "),
+		Message: "exactly one of these arguments must be given",
+	}}}
+}
+
+// deletionTarget is an application selected for deletion.
+type deletionTarget struct {
+	id  string
+	app map[string]interface{}
+}
+
+// deletionReport describes what is affected by deleting an application.
+type deletionReport struct {
+	ID                  string        `json:"id"`
+	Name                string        `json:"name"`
+	ClientID            string        `json:"client_id,omitempty"`
+	AuthorizedAPIs      []interface{} `json:"authorized_apis"`
+	RoleAudience        string        `json:"role_audience,omitempty"`
+	Roles               []interface{} `json:"roles"`
+	SharedOrganizations []interface{} `json:"shared_organizations"`
+	Warnings            []string      `json:"warnings,omitempty"`
+}
+
+// deletionFailure is an application that could not be deleted.
+type deletionFailure struct {
+	ID    string                `json:"id"`
+	Name  string                `json:"name"`
+	Error asgardeo.ErrorDetails `json:"error"`
+}
+
+func GetDeleteApplicationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	deleteApplicationTool := mcp.NewTool("delete_application",
+		mcp.WithDescription(fmt.Sprintf("Delete applications in %s. Identify one application by id, name or client_id, or several by filter (at most %d). "+
+			"A call without confirmation_token deletes nothing: it reports the authorized APIs, roles and shared organizations affected by the deletion and returns a confirmation_token. "+
+			"Show the report to the user and call again with the same arguments and the confirmation_token to delete.", productName, config.BULK_DELETE_LIMIT)),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("id", mcp.Description("ID of the application")),
+		mcp.WithString("name", mcp.Description("Name of the application")),
+		mcp.WithString("client_id", mcp.Description("Client ID of the application")),
+		mcp.WithString("filter",
+			mcp.Description(fmt.Sprintf("Filter expression selecting the applications to delete, with the syntax of list_applications. Eg: name sw test-. It must match at most %d applications.", config.BULK_DELETE_LIMIT)),
+		),
+		mcp.WithString("confirmation_token",
+			mcp.Description("Token returned by the preview call. It expires after a few minutes and is only valid for the same applications."),
+		),
+	)
+
+	deleteApplicationToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			ID                string `json:"id"`
+			Name              string `json:"name"`
+			ClientID          string `json:"client_id"`
+			Filter            string `json:"filter"`
+			ConfirmationToken string `json:"confirmation_token"`
+		}
+		if err := utils.BindArguments(req, deleteApplicationTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := requireOneOf(map[string]string{"id": args.ID, "name": args.Name, "client_id": args.ClientID, "filter": args.Filter}); err != nil {
+			return toolError(err)
+		}
+		if err := validateApplicationFilter(args.Filter); err != nil {
+			return toolError(err)
+		}
+
+		profile, err := asgardeo.ResolveProfile(ctx)
+		if err != nil {
+			return toolError(err)
+		}
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var ids []string
+		if args.Filter != "" {
+			list, err := asgardeo.ListApplications(ctx, client, asgardeo.ApplicationListParams{Limit: config.BULK_DELETE_LIMIT + 1, Filter: args.Filter})
+			if err != nil {
+				log.Printf("Error listing applications: %v", err)
+				return toolError(err)
+			}
+			if list.TotalResults > config.BULK_DELETE_LIMIT || len(list.Applications) > config.BULK_DELETE_LIMIT {
+				return toolError(&utils.ArgumentError{Fields: []utils.FieldError{{
+					Field:   "filter",
+					Message: fmt.Sprintf("matches %d applications but at most %d can be deleted at once; narrow the filter", max(list.TotalResults, len(list.Applications)), config.BULK_DELETE_LIMIT),
+				}}})
+			}
+			for _, app := range list.Applications {
+				ids = append(ids, app.ID)
+			}
+			if len(ids) == 0 {
+				return toolResult(ctx, messageResult{Message: "No application matches the filter. Nothing was deleted."})
+			}
+		} else {
+			appID := args.ID
+			if appID == "" {
+				if appID, err = asgardeo.FindApplicationID(ctx, client, args.Name, args.ClientID); err != nil {
+					log.Printf("Error finding application: %v", err)
+					return toolError(err)
+				}
+			}
+			ids = []string{appID}
+		}
+
+		targets := make([]deletionTarget, 0, len(ids))
+		subjects := []string{profile.Name}
+		for _, id := range ids {
+			app, err := asgardeo.GetApplication(ctx, client, id)
+			if err != nil {
+				log.Printf("Error retrieving application: %v", err)
+				return toolError(err)
+			}
+			targets = append(targets, deletionTarget{id: id, app: app})
+			subjects = append(subjects, id)
+		}
+
+		if args.ConfirmationToken == "" {
+			reports := make([]deletionReport, 0, len(targets))
+			for _, target := range targets {
+				report, err := reportDeletion(ctx, client, target)
+				if err != nil {
+					return toolError(err)
+				}
+				reports = append(reports, report)
+			}
+			token, expiresAt := newConfirmationToken("delete_application", subjects)
+			return toolResult(ctx, map[string]interface{}{
+				"applications":       reports,
+				"confirmation_token": token,
+				"expires_at":         expiresAt.UTC().Format(time.RFC3339),
+				"message": fmt.Sprintf("Nothing was deleted. Review the affected resources, then call delete_application again with the same arguments and confirmation_token to delete %d application(s) before the token expires.",
+					len(reports)),
+			})
+		}
+
+		if err := verifyConfirmationToken(args.ConfirmationToken, "delete_application", subjects); err != nil {
+			return toolError(&utils.ArgumentError{Fields: []utils.FieldError{{Field: "confirmation_token", Message: err.Error()}}})
+		}
+
+		deleted := []applicationSummary{}
+		failed := []deletionFailure{}
+		for _, target := range targets {
+			name, _ := target.app["name"].(string)
+			if err := asgardeo.DeleteApplication(ctx, client, target.id); err != nil {
+				log.Printf("Error deleting application %s: %v", target.id, err)
+				if len(targets) == 1 {
+					return toolError(err)
+				}
+				failed = append(failed, deletionFailure{ID: target.id, Name: name, Error: asgardeo.ClassifyError(err)})
+				continue
+			}
+			clientID, _ := target.app["clientId"].(string)
+			deleted = append(deleted, applicationSummary{ID: target.id, Name: name, ClientID: clientID})
+		}
+		return toolResult(ctx, map[string]interface{}{
+			"deleted": deleted,
+			"failed":  failed,
+		})
+	}
+
+	return deleteApplicationTool, deleteApplicationToolImpl
+}
+
+// reportDeletion collects the authorized APIs, roles and shared organizations of an application
+// that is about to be deleted.
+func reportDeletion(ctx context.Context, client *sdk.Client, target deletionTarget) (deletionReport, error) {
+	report := deletionReport{
+		ID:                  target.id,
+		AuthorizedAPIs:      []interface{}{},
+		Roles:               []interface{}{},
+		SharedOrganizations: []interface{}{},
+	}
+	report.Name, _ = target.app["name"].(string)
+	report.ClientID, _ = target.app["clientId"].(string)
+
+	authorizedAPIs, err := asgardeo.GetAuthorizedAPIs(ctx, client, target.id)
+	if err != nil {
+		log.Printf("Error listing authorized APIs: %v", err)
+		return report, err
+	}
+	report.AuthorizedAPIs = authorizedAPIs
+
+	if associatedRoles, ok := target.app["associatedRoles"].(map[string]interface{}); ok {
+		report.RoleAudience, _ = associatedRoles["allowedAudience"].(string)
+		if roles, ok := associatedRoles["roles"].([]interface{}); ok {
+			report.Roles = roles
+		}
+		if strings.EqualFold(report.RoleAudience, "application") && len(report.Roles) > 0 {
+			report.Warnings = append(report.Warnings, "The application audience roles are deleted with the application and their users lose them.")
+		}
+	}
+
+	// Organization sharing is not available in every deployment, so a failure to read it is
+	// reported instead of blocking the deletion.
+	sharedOrganizations, err := asgardeo.GetSharedOrganizations(ctx, client, target.id)
+	if err != nil {
+		log.Printf("Error listing shared organizations: %v", err)
+		report.Warnings = append(report.Warnings, "Could not read the organizations the application is shared with: "+asgardeo.ClassifyError(err).Message)
+	} else {
+		report.SharedOrganizations = sharedOrganizations
+		if len(sharedOrganizations) > 0 {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("The application is shared with %d organization(s) and is removed from them as well.", len(sharedOrganizations)))
+		}
+	}
+	return report, nil
+}
+
+// applicationReference are the arguments that identify one application.
+type applicationReference struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ClientID string `json:"client_id"`
+}
+
+// withApplicationReference adds the arguments that identify one application to a tool.
+func withApplicationReference() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString("id", mcp.Description("ID of the application"))(tool)
+		mcp.WithString("name", mcp.Description("Name of the application"))(tool)
+		mcp.WithString("client_id", mcp.Description("Client ID of the application"))(tool)
+	}
+}
+
+// resolve returns the ID of the referenced application, looking it up by name or client ID.
+func (ref applicationReference) resolve(ctx context.Context, client *sdk.Client) (string, error) {
+	if err := requireOneOf(map[string]string{"id": ref.ID, "name": ref.Name, "client_id": ref.ClientID}); err != nil {
+		return "", err
+	}
+	if ref.ID != "" {
+		return ref.ID, nil
+	}
+	appID, err := asgardeo.FindApplicationID(ctx, client, ref.Name, ref.ClientID)
+	if err != nil {
+		log.Printf("Error finding application: %v", err)
+	}
+	return appID, err
+}
+
+func GetRegenerateClientSecretTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
 
+	regenerateSecretTool := mcp.NewTool("regenerate_client_secret",
+		mcp.WithDescription(fmt.Sprintf("Generate a new client secret for the OIDC configuration of an application in %s, eg: after the secret leaked. "+
+			"The previous secret stops working immediately. The new secret is delivered to the chosen secret sink, which must not be redact, and never returned in the result. "+
+			"Identify the application by exactly one of id, name or client_id.", productName)),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		withApplicationReference(),
+		withSecretSink(),
+	)
+
+	regenerateSecretToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			applicationReference
+			secretSinkArguments
+		}
+		if err := utils.BindArguments(req, regenerateSecretTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := args.secretSinkArguments.checkRotation(); err != nil {
+			return toolError(err)
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+		appID, err := args.applicationReference.resolve(ctx, client)
+		if err != nil {
+			return toolError(err)
+		}
+
+		oidcConfig, err := asgardeo.RegenerateClientSecret(ctx, client, appID)
+		if err != nil {
+			log.Printf("Error regenerating client secret: %v", err)
+			return toolError(err)
+		}
+		return deliverClientSecret(ctx, args.secretSinkArguments, asgardeo.ResolveOIDCEndpoints(ctx, client), appID, oidcConfig,
+			"A new client secret was generated; the previous secret no longer works. Update the applications that use it.")
+	}
+
+	return regenerateSecretTool, regenerateSecretToolImpl
+}
+
+func GetRevokeApplicationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	revokeApplicationTool := mcp.NewTool("revoke_application",
+		mcp.WithDescription(fmt.Sprintf("Revoke the OIDC configuration of an application in %s. Its client secret and the tokens issued to it stop working "+
+			"until it is reactivated with reactivate_application. Identify the application by exactly one of id, name or client_id.", productName)),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		withApplicationReference(),
+	)
+
+	revokeApplicationToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args applicationReference
+		if err := utils.BindArguments(req, revokeApplicationTool, &args); err != nil {
+			return toolError(err)
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+		appID, err := args.resolve(ctx, client)
+		if err != nil {
+			return toolError(err)
+		}
+
+		if err := asgardeo.RevokeOIDC(ctx, client, appID); err != nil {
+			log.Printf("Error revoking application: %v", err)
+			return toolError(err)
+		}
+		return toolResult(ctx, map[string]interface{}{
+			"id":      appID,
+			"state":   "REVOKED",
+			"message": "The OIDC configuration of the application was revoked. Its client secret and issued tokens no longer work; use reactivate_application to enable it again with a new secret.",
+		})
+	}
+
+	return revokeApplicationTool, revokeApplicationToolImpl
+}
+
+func GetReactivateApplicationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	reactivateApplicationTool := mcp.NewTool("reactivate_application",
+		mcp.WithDescription(fmt.Sprintf("Reactivate the revoked OIDC configuration of an application in %s. The server issues a new client secret, "+
+			"which is delivered to the chosen secret sink, which must not be redact, and never returned in the result. Identify the application by exactly one of id, name or client_id.", productName)),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		withApplicationReference(),
+		withSecretSink(),
+	)
+
+	reactivateApplicationToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			applicationReference
+			secretSinkArguments
+		}
+		if err := utils.BindArguments(req, reactivateApplicationTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := args.secretSinkArguments.checkRotation(); err != nil {
+			return toolError(err)
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+		appID, err := args.applicationReference.resolve(ctx, client)
+		if err != nil {
+			return toolError(err)
+		}
+
+		oidcConfig, err := asgardeo.ReactivateOIDC(ctx, client, appID)
+		if err != nil {
+			log.Printf("Error reactivating application: %v", err)
+			return toolError(err)
+		}
+		return deliverClientSecret(ctx, args.secretSinkArguments, asgardeo.ResolveOIDCEndpoints(ctx, client), appID, oidcConfig,
+			"The OIDC configuration of the application was reactivated with a new client secret.")
+	}
+
+	return reactivateApplicationTool, reactivateApplicationToolImpl
+}
+
+// secretNotDelivered returns the result of a tool call that created an application but could not
+// deliver its client secret: an error that still reports the application, so that it is not
+// created again.
+func secretNotDelivered(err error, response map[string]interface{}) (*mcp.CallToolResult, error) {
+	log.Printf("Error delivering client secret: %v", err)
+	response["message"] = "The application was created, but its client secret could not be delivered and is lost. " +
+		"Do not create the application again; fix the secret sink and run regenerate_client_secret for it."
+	return partialToolError(err, response)
+}
+
+// deliverClientSecret writes the client secret of an OIDC configuration to the secret sink and
+// returns the result that refers to it by location.
+func deliverClientSecret(ctx context.Context, sink secretSinkArguments, endpoints asgardeo.OIDCEndpoints, appID string, oidcConfig map[string]interface{}, message string) (*mcp.CallToolResult, error) {
+	clientID, _ := oidcConfig["clientId"].(string)
+	response := map[string]interface{}{
+		"id":        appID,
+		"client_id": clientID,
+		"message":   message,
+	}
+	if state, ok := oidcConfig["state"].(string); ok {
+		response["state"] = state
+	}
+
+	secret, _ := oidcConfig["clientSecret"].(string)
+	if secret == "" {
+		response["message"] = message + " The server did not return the client secret; regenerate it with regenerate_client_secret to obtain it."
+		return toolResult(ctx, response)
+	}
+	ref, err := deliverSecret(sink, fmt.Sprintf("Client secret of application %s (client ID %s)", appID, clientID),
+		clientSecret{BaseURL: endpoints.BaseURL, Issuer: endpoints.Issuer, ClientID: clientID, Secret: secret})
 	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
+		log.Printf("Error delivering client secret: %v", err)
+		response["message"] = "A new client secret was issued but could not be delivered, so it is lost; the previous secret no longer works. " +
+			"Fix the secret sink and run regenerate_client_secret to issue a usable secret."
+		return partialToolError(err, response)
 	}
+	response["client_secret"] = ref
+	return toolResult(ctx, response)
+}
+
+func GetSearchApplicationByNameTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
 
 	getApplicationByNameTool := mcp.NewTool("get_application_by_name",
 		mcp.WithDescription(fmt.Sprintf("Get details of an application by name in %s", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget(""),
 		mcp.WithString("application_name", mcp.Description("Name of the application"), mcp.Required()),
 	)
 
 	getApplicationByNameToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appName := req.Params.Arguments["application_name"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
 
-		app, err := client.Application.GetByName(ctx, appName)
+		var args struct {
+			ApplicationName string `json:"application_name"`
+		}
+		if err := utils.BindArguments(req, getApplicationByNameTool, &args); err != nil {
+			return toolError(err)
+		}
+
+		app, err := client.Application.GetByName(ctx, args.ApplicationName)
 		if err != nil {
 			log.Printf("Error retrieving app: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		baseURL := client.Config.BaseURL
+		endpoints := asgardeo.ResolveOIDCEndpoints(ctx, client)
 		response := map[string]interface{}{
 			"application_configurations": map[string]string{
 				"name":             app.Name,
@@ -304,20 +1206,10 @@ func GetSearchApplicationByNameTool() (mcp.Tool, server.ToolHandlerFunc) {
 				"scope":            app.AuthorizedScopes,
 				"application_type": string(app.AppType),
 			},
-			"oauth_endpoints": map[string]string{
-				"base_url":      baseURL,
-				"authorize_url": fmt.Sprintf("%s/oauth2/authorize", baseURL),
-				"token_url":     fmt.Sprintf("%s/oauth2/token", baseURL),
-				"jwks_url":      fmt.Sprintf("%s/oauth2/jwks", baseURL),
-				"userinfo_url":  fmt.Sprintf("%s/oauth2/userinfo", baseURL),
-			},
+			"oauth_endpoints": endpoints,
 		}
 
-		jsonData, err := utils.MarshalResponse(response)
-		if err != nil {
-			return nil, err
-		}
-		return mcp.NewToolResultText(jsonData), nil
+		return toolResult(ctx, response)
 	}
 
 	return getApplicationByNameTool, getApplicationByNameToolImpl
@@ -325,27 +1217,36 @@ func GetSearchApplicationByNameTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetSearchApplicationByClientIdTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	getApplicationByClientIDTool := mcp.NewTool("get_application_by_client_id",
 		mcp.WithDescription(fmt.Sprintf("Get details of an application by client ID in %s", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget(""),
 		mcp.WithString("client_id", mcp.Description("Client ID of the application"), mcp.Required()),
 	)
 
 	getApplicationByClientIDToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appName := req.Params.Arguments["client_id"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
 
-		app, err := client.Application.GetByClienId(ctx, appName)
+		var args struct {
+			ClientID string `json:"client_id"`
+		}
+		if err := utils.BindArguments(req, getApplicationByClientIDTool, &args); err != nil {
+			return toolError(err)
+		}
+
+		app, err := client.Application.GetByClienId(ctx, args.ClientID)
 		if err != nil {
 			log.Printf("Error retrieving app: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		baseURL := client.Config.BaseURL
+		endpoints := asgardeo.ResolveOIDCEndpoints(ctx, client)
 		response := map[string]interface{}{
 			"application_configurations": map[string]string{
 				"name":             app.Name,
@@ -355,20 +1256,10 @@ func GetSearchApplicationByClientIdTool() (mcp.Tool, server.ToolHandlerFunc) {
 				"scope":            app.AuthorizedScopes,
 				"application_type": string(app.AppType),
 			},
-			"oauth_endpoints": map[string]string{
-				"base_url":      baseURL,
-				"authorize_url": fmt.Sprintf("%s/oauth2/authorize", baseURL),
-				"token_url":     fmt.Sprintf("%s/oauth2/token", baseURL),
-				"jwks_url":      fmt.Sprintf("%s/oauth2/jwks", baseURL),
-				"userinfo_url":  fmt.Sprintf("%s/oauth2/userinfo", baseURL),
-			},
+			"oauth_endpoints": endpoints,
 		}
 
-		jsonData, err := utils.MarshalResponse(response)
-		if err != nil {
-			return nil, err
-		}
-		return mcp.NewToolResultText(jsonData), nil
+		return toolResult(ctx, response)
 	}
 
 	return getApplicationByClientIDTool, getApplicationByClientIDToolImpl
@@ -376,11 +1267,6 @@ func GetSearchApplicationByClientIdTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetUpdateApplicationBasicInfoTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	updateApplicationBasicInfoTool := mcp.NewTool("update_application_basic_info",
 		mcp.WithDescription(fmt.Sprintf("Update basic information of an application in %s", productName)),
@@ -393,35 +1279,48 @@ func GetUpdateApplicationBasicInfoTool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	updateApplicationBasicInfoToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appId := req.Params.Arguments["id"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
 
-		basicInfoUpdate := application.NewBasicInfoUpdate()
-		if name, ok := req.Params.Arguments["name"]; ok && name != nil {
-			basicInfoUpdate.WithName(name.(string))
+		var args struct {
+			ID              string  `json:"id"`
+			Name            *string `json:"name"`
+			Description     *string `json:"description"`
+			ImageURL        *string `json:"image_url"`
+			AccessURL       *string `json:"access_url"`
+			LogoutReturnURL *string `json:"logout_return_url"`
+		}
+		if err := utils.BindArguments(req, updateApplicationBasicInfoTool, &args); err != nil {
+			return toolError(err)
 		}
-		if description, ok := req.Params.Arguments["description"]; ok && description != nil {
-			basicInfoUpdate.WithDescription(description.(string))
+
+		basicInfoUpdate := application.NewBasicInfoUpdate()
+		if args.Name != nil {
+			basicInfoUpdate.WithName(*args.Name)
 		}
-		if imageUrl, ok := req.Params.Arguments["image_url"]; ok && imageUrl != nil {
-			basicInfoUpdate.WithImageUrl(imageUrl.(string))
+		if args.Description != nil {
+			basicInfoUpdate.WithDescription(*args.Description)
 		}
-		if accessUrl, ok := req.Params.Arguments["access_url"]; ok && accessUrl != nil {
-			basicInfoUpdate.WithAccessUrl(accessUrl.(string))
+		if args.ImageURL != nil {
+			basicInfoUpdate.WithImageUrl(*args.ImageURL)
 		}
-		if logoutReturnUrl, ok := req.Params.Arguments["logout_return_url"]; ok && logoutReturnUrl != nil {
-			basicInfoUpdate.WithLogoutReturnUrl(logoutReturnUrl.(string))
+		if args.AccessURL != nil {
+			basicInfoUpdate.WithAccessUrl(*args.AccessURL)
 		}
-		if name, ok := req.Params.Arguments["name"]; ok && name != nil {
-			basicInfoUpdate.WithName(name.(string))
+		if args.LogoutReturnURL != nil {
+			basicInfoUpdate.WithLogoutReturnUrl(*args.LogoutReturnURL)
 		}
 
-		err := client.Application.UpdateBasicInfo(ctx, appId, *basicInfoUpdate)
+		err = client.Application.UpdateBasicInfo(ctx, args.ID, *basicInfoUpdate)
 		if err != nil {
 			log.Printf("Error updating application: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		return mcp.NewToolResultText("Successfully updated the application."), nil
+		return toolResult(ctx, messageResult{Message: "Successfully updated the application."})
 	}
 
 	return updateApplicationBasicInfoTool, updateApplicationBasicInfoToolImpl
@@ -429,64 +1328,51 @@ func GetUpdateApplicationBasicInfoTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetUpdateApplicationOAuthConfigTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
-
-	stringTypeSchema := map[string]interface{}{"type": "string"}
 
 	updateApplicationOAuthConfigTool := mcp.NewTool("update_application_oauth_config",
-		mcp.WithDescription(fmt.Sprintf("Update OAuth/OIDC configurations of an application in %s", productName)),
+		mcp.WithDescription(fmt.Sprintf("Update OAuth/OIDC configurations of an application in %s: redirect URLs, allowed origins, grant types, PKCE, public client, "+
+			"access token type, binding and expiry, refresh token rotation and expiry, ID token encryption, back-channel logout and request object signing. "+
+			"Only the given settings are changed; the others keep their current values.", productName)),
 		mcp.WithString("id", mcp.Description("ID of the application"), mcp.Required()),
-		mcp.WithArray("redirect_urls", mcp.Description("Redirect URLs of the application"), mcp.Items(stringTypeSchema)),
-		mcp.WithNumber("user_access_token_expiry_time", mcp.Description("Expiry time of the access token issued on behalf of the user")),
-		mcp.WithNumber("application_access_token_expiry_time", mcp.Description("Expiry time of the access token issued on behalf of the application")),
-		mcp.WithNumber("refresh_token_expiry_time", mcp.Description("Expiry time of the refresh token")),
-		mcp.WithArray("allowed_origins", mcp.Description("Allowed origins for CORS"), mcp.Items(stringTypeSchema)),
-		mcp.WithBoolean("revoke_tokens_when_idp_session_terminated", mcp.Description("Revoke tokens when IDP session is terminated")),
-		mcp.WithArray("access_token_attributes", mcp.Description("Access token attributes"), mcp.Items(stringTypeSchema)),
+		withOIDCSettings(),
 	)
 
 	updateApplicationOAuthConfigToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appId := req.Params.Arguments["id"].(string)
-
-		OAuthConfigUpdate := application.NewOAuthConfigUpdate()
-		if redirectURLs, ok := req.Params.Arguments["redirect_urls"]; ok && redirectURLs != nil {
-			urls := convertToStringSlice(redirectURLs)
-			OAuthConfigUpdate.WithCallbackURLs(urls)
+		var args struct {
+			ID string `json:"id"`
+			oidcSettings
 		}
-
-		if allowedOrigins, ok := req.Params.Arguments["allowed_origins"]; ok && allowedOrigins != nil {
-			origins := convertToStringSlice(allowedOrigins)
-			OAuthConfigUpdate.WithAllowedOrigins(origins)
+		if err := utils.BindArguments(req, updateApplicationOAuthConfigTool, &args); err != nil {
+			return toolError(err)
 		}
 
-		if userExpiry, ok := req.Params.Arguments["user_access_token_expiry_time"]; ok && userExpiry != nil {
-			OAuthConfigUpdate.WithUserAccessTokenExpiry(int64(userExpiry.(float64)))
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
 		}
 
-		if appExpiry, ok := req.Params.Arguments["application_access_token_expiry_time"]; ok && appExpiry != nil {
-			OAuthConfigUpdate.WithApplicationAccessTokenExpiry(int64(appExpiry.(float64)))
+		oidcConfig, err := asgardeo.GetInboundProtocol(ctx, client, args.ID, "oidc")
+		if err != nil {
+			log.Printf("Error retrieving OAuth configuration of application: %v", err)
+			return toolError(err)
 		}
-
-		if refreshExpiry, ok := req.Params.Arguments["refresh_token_expiry_time"]; ok && refreshExpiry != nil {
-			OAuthConfigUpdate.WithRefreshTokenExpiry(int64(refreshExpiry.(float64)))
+		// Problems that the configuration already had are left for the user to fix separately.
+		existingProblems := oidcConfigProblems(oidcConfig)
+		applied := args.oidcSettings.apply(oidcConfig)
+		if len(applied) == 0 {
+			return toolResult(ctx, messageResult{Message: "No OAuth settings were given; the application was not changed."})
 		}
-
-		if attributes, ok := req.Params.Arguments["access_token_attributes"]; ok && attributes != nil {
-			attrs := convertToStringSlice(attributes)
-			OAuthConfigUpdate.WithAccessTokenAttributes(attrs)
+		if err := newProblems(existingProblems, oidcConfigProblems(oidcConfig)); err != nil {
+			return toolError(err)
 		}
 
-		err := client.Application.UpdateOAuthConfig(ctx, appId, *OAuthConfigUpdate)
-		if err != nil {
+		if err := asgardeo.UpdateInboundProtocol(ctx, client, args.ID, "oidc", oidcConfig); err != nil {
 			log.Printf("Error updating application: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		return mcp.NewToolResultText("Successfully updated the application."), nil
+		return toolResult(ctx, messageResult{Message: "Successfully updated the application: " + strings.Join(applied, ", ") + "."})
 	}
 
 	return updateApplicationOAuthConfigTool, updateApplicationOAuthConfigToolImpl
@@ -494,61 +1380,108 @@ func GetUpdateApplicationOAuthConfigTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetUpdateApplicationClaimConfigTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
 
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
+	// A claim is either its URI or an object with the URI and its flags.
+	claimSchema := map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"type": "string"},
+			map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"uri":       map[string]interface{}{"type": "string"},
+					"mandatory": map[string]interface{}{"type": "boolean"},
+					"requested": map[string]interface{}{"type": "boolean"},
+					"alias":     map[string]interface{}{"type": "string"},
+				},
+				"required": []interface{}{"uri"},
+			},
+		},
 	}
 
-	stringTypeSchema := map[string]interface{}{"type": "string"}
-
 	updateApplicationClaimConfigTool := mcp.NewTool("update_application_claim_config",
-		mcp.WithDescription(fmt.Sprintf("Update claim configurations of an application in %s", productName)),
+		mcp.WithDescription(fmt.Sprintf("Update claim configurations of an application in %s: the requested claims with their mandatory flag and alias, "+
+			"the subject claim and the role claim. With mode add or remove the claims are merged with the current configuration instead of replacing it.", productName)),
 		mcp.WithString("id",
 			mcp.Description("ID of the application"),
 			mcp.Required(),
 		),
 		mcp.WithArray("claims",
-			mcp.Description("List of claims to be added as requested claims in the application. Eg: list of URIs like http://wso2.org/claims/username, http://wso2.org/claims/emailaddress"),
-			mcp.Items(stringTypeSchema),
-			mcp.Required(),
+			mcp.Description("Claims of the application, as local claim URIs like http://wso2.org/claims/username, or as objects with the uri, "+
+				"mandatory (default false), requested (default true; false keeps only the alias mapping) and alias (the name the application receives the claim as). "+
+				"Eg: [\"http://wso2.org/claims/username\", {\"uri\": \"http://wso2.org/claims/emailaddress\", \"mandatory\": true, \"alias\": \"email\"}]"),
+			mcp.Items(claimSchema),
+		),
+		mcp.WithString("mode",
+			mcp.Description("replace sets the claims to the given list; add adds the given claims or changes the given flags of existing ones; remove removes the given claims"),
+			mcp.Enum(claimModeReplace, claimModeAdd, claimModeRemove),
+			mcp.DefaultString(claimModeReplace),
 		),
+		mcp.WithString("subject_claim", mcp.Description("Local claim URI that identifies the user to the application, eg: http://wso2.org/claims/emailaddress")),
+		mcp.WithBoolean("subject_include_tenant_domain", mcp.Description("Append the tenant domain to the subject")),
+		mcp.WithBoolean("subject_include_userstore_domain", mcp.Description("Prepend the user store domain to the subject")),
+		mcp.WithString("role_claim", mcp.Description("Local claim URI that carries the roles of the user, eg: http://wso2.org/claims/roles")),
 	)
 
 	updateApplicationClaimConfigToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appId := req.Params.Arguments["id"].(string)
-		claimsInput, ok := req.Params.Arguments["claims"].([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("invalid claim format: expected an array of strings")
+		var args struct {
+			ID                            string          `json:"id"`
+			Claims                        []claimArgument `json:"claims"`
+			Mode                          string          `json:"mode"`
+			SubjectClaim                  string          `json:"subject_claim"`
+			SubjectIncludeTenantDomain    *bool           `json:"subject_include_tenant_domain"`
+			SubjectIncludeUserstoreDomain *bool           `json:"subject_include_userstore_domain"`
+			RoleClaim                     string          `json:"role_claim"`
 		}
-
-		claimConfigs := make([]application.RequestedClaimModel, len(claimsInput))
-		mandatory := false
-
-		for i, claimInput := range claimsInput {
-			uri, uriOk := claimInput.(string)
-			if !uriOk {
-				return nil, fmt.Errorf("invalid claim URI at index %d: expected a string", i)
-			}
-
-			claimConfigs[i] = application.RequestedClaimModel{
-				Claim: application.ClaimModel{
-					Uri: uri,
-				},
-				Mandatory: &mandatory,
-			}
+		if err := utils.BindArguments(req, updateApplicationClaimConfigTool, &args); err != nil {
+			return toolError(err)
+		}
+		if args.Claims == nil && args.SubjectClaim == "" && args.SubjectIncludeTenantDomain == nil &&
+			args.SubjectIncludeUserstoreDomain == nil && args.RoleClaim == "" {
+			return toolResult(ctx, messageResult{Message: "No claim settings were given; the application was not changed."})
 		}
 
-		claimConfiguration := application.ApplicationClaimConfigurationUpdateModel{
-			RequestedClaims: &claimConfigs,
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
 		}
-		err := client.Application.UpdateClaimConfig(ctx, appId, claimConfiguration)
+		app, err := asgardeo.GetApplication(ctx, client, args.ID)
 		if err != nil {
+			log.Printf("Error retrieving application: %v", err)
+			return toolError(err)
+		}
+
+		current, _ := app["claimConfiguration"].(map[string]interface{})
+		claimConfiguration := parseClaimConfig(current)
+		if args.Claims != nil {
+			claimConfiguration.applyClaims(args.Mode, args.Claims)
+		}
+		if args.SubjectClaim != "" {
+			claimConfiguration.SubjectClaim = args.SubjectClaim
+		}
+		if args.SubjectIncludeTenantDomain != nil {
+			claimConfiguration.SubjectIncludeTenant = *args.SubjectIncludeTenantDomain
+		}
+		if args.SubjectIncludeUserstoreDomain != nil {
+			claimConfiguration.SubjectIncludeUserstore = *args.SubjectIncludeUserstoreDomain
+		}
+		if args.RoleClaim != "" {
+			claimConfiguration.RoleClaim = args.RoleClaim
+		}
+		if problems := claimConfiguration.problems(); len(problems) > 0 {
+			return toolError(&utils.ArgumentError{Fields: problems})
+		}
+
+		patch := map[string]interface{}{"claimConfiguration": claimConfiguration.toAPI()}
+		if err := asgardeo.PatchApplication(ctx, client, args.ID, patch); err != nil {
 			log.Printf("Error updating the claim configuration of the application: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		return mcp.NewToolResultText("Successfully updated the claim configuration of the application."), nil
+		return toolResult(ctx, map[string]interface{}{
+			"message":             "Successfully updated the claim configuration of the application.",
+			"claim_configuration": claimConfiguration,
+		})
 	}
 
 	return updateApplicationClaimConfigTool, updateApplicationClaimConfigToolImpl
@@ -556,11 +1489,6 @@ func GetUpdateApplicationClaimConfigTool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetAuthorizeAPITool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	stringTypeSchema := map[string]interface{}{"type": "string"}
 
@@ -587,27 +1515,35 @@ func GetAuthorizeAPITool() (mcp.Tool, server.ToolHandlerFunc) {
 		),
 	)
 	authorizeAPIToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appId := req.Params.Arguments["appId"].(string)
-		id := req.Params.Arguments["id"].(string)
-		policyIdentifier := req.Params.Arguments["policyIdentifier"].(string)
-		rawScopes := req.Params.Arguments["scopes"].([]interface{})
-		scopes := make([]string, len(rawScopes))
-		for i, s := range rawScopes {
-			scopes[i] = s.(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
 		}
+
+		var args struct {
+			AppID            string   `json:"appId"`
+			ID               string   `json:"id"`
+			PolicyIdentifier string   `json:"policyIdentifier"`
+			Scopes           []string `json:"scopes"`
+		}
+		if err := utils.BindArguments(req, authorizeAPITool, &args); err != nil {
+			return toolError(err)
+		}
+
 		authorizedAPI := application.AuthorizedAPICreateModel{
-			Id:               &id,
-			PolicyIdentifier: &policyIdentifier,
-			Scopes:           &scopes,
+			Id:               &args.ID,
+			PolicyIdentifier: &args.PolicyIdentifier,
+			Scopes:           &args.Scopes,
 		}
 
-		err := client.Application.AuthorizeAPI(ctx, appId, authorizedAPI)
+		err = client.Application.AuthorizeAPI(ctx, args.AppID, authorizedAPI)
 		if err != nil {
 			log.Printf("Error authorizing API resource: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 
-		return mcp.NewToolResultText("API authorization successful."), nil
+		return toolResult(ctx, messageResult{Message: "API authorization successful."})
 	}
 
 	return authorizeAPITool, authorizeAPIToolImpl
@@ -615,14 +1551,12 @@ func GetAuthorizeAPITool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetListAuthorizedAPITool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	authorizedAPIListTool := mcp.NewTool("list_authorized_api",
 		mcp.WithDescription(fmt.Sprintf("List authorized API resources of an application in %s", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget("authorized_apis"),
 		mcp.WithString("app_id",
 			mcp.Required(),
 			mcp.Description("This is the id of the application."),
@@ -630,12 +1564,23 @@ func GetListAuthorizedAPITool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	authorizedAPIListToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		appId := req.Params.Arguments["app_id"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var args struct {
+			AppID string `json:"app_id"`
+		}
+		if err := utils.BindArguments(req, authorizedAPIListTool, &args); err != nil {
+			return toolError(err)
+		}
 
-		resp, err := client.Application.GetAuthorizedAPIs(ctx, appId)
+		resp, err := client.Application.GetAuthorizedAPIs(ctx, args.AppID)
 		if err != nil {
 			log.Printf("Error listing authorized APIs: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 		authorizedAPIs := []interface{}{}
 		for _, api := range *resp {
@@ -657,11 +1602,7 @@ func GetListAuthorizedAPITool() (mcp.Tool, server.ToolHandlerFunc) {
 			})
 		}
 
-		jsonData, err := utils.MarshalResponse(authorizedAPIs)
-		if err != nil {
-			return nil, err
-		}
-		return mcp.NewToolResultText(jsonData), nil
+		return toolResult(ctx, map[string]interface{}{"authorized_apis": authorizedAPIs})
 	}
 
 	return authorizedAPIListTool, authorizedAPIListToolImpl
@@ -669,11 +1610,6 @@ func GetListAuthorizedAPITool() (mcp.Tool, server.ToolHandlerFunc) {
 
 func GetUpdateLoginFlowTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	updateLoginFlowTool := mcp.NewTool("update_login_flow",
 		mcp.WithDescription(fmt.Sprintf("Update login flow in an application for given user prompt in %s", productName)),
@@ -693,13 +1629,24 @@ func GetUpdateLoginFlowTool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	updateLoginFlowToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		userPrompt := req.Params.Arguments["user_prompt"].(string)
-		appId := req.Params.Arguments["app_id"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var args struct {
+			AppID      string `json:"app_id"`
+			UserPrompt string `json:"user_prompt"`
+		}
+		if err := utils.BindArguments(req, updateLoginFlowTool, &args); err != nil {
+			return toolError(err)
+		}
 
-		loginFlowResponse, err := client.Application.GenerateLoginFlow(ctx, userPrompt)
+		loginFlowResponse, err := client.Application.GenerateLoginFlow(ctx, args.UserPrompt)
 		if err != nil {
 			log.Printf("Error generating login flow: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 		flowId := loginFlowResponse.OperationId
 		var statusResponse *application.LoginFlowStatusResponseModel
@@ -707,7 +1654,7 @@ func GetUpdateLoginFlowTool() (mcp.Tool, server.ToolHandlerFunc) {
 			statusResponse, err = client.Application.GetLoginFlowGenerationStatus(ctx, *flowId)
 			if err != nil {
 				log.Printf("Error getting login flow generation status: %v", err)
-				return nil, err
+				return toolError(err)
 			}
 			if statusResponse.Status != nil {
 				allTrue := true
@@ -728,26 +1675,25 @@ func GetUpdateLoginFlowTool() (mcp.Tool, server.ToolHandlerFunc) {
 		resultResponse, err := client.Application.GetLoginFlowGenerationResult(ctx, *flowId)
 		if err != nil {
 			log.Printf("Error getting login flow generation result: %v", err)
-			return nil, err
+			return toolError(err)
 		}
 		loginFlowResultData := *resultResponse.Data
-		err = client.Application.UpdateLoginFlow(ctx, appId, loginFlowResultData)
+		err = client.Application.UpdateLoginFlow(ctx, args.AppID, loginFlowResultData)
 
 		if err != nil {
 			log.Printf("Error updating login flow: %v", err)
-			return nil, err
+			return toolError(err)
 		}
-		return mcp.NewToolResultText("Login flow generated sucessfully."), nil
+		return toolResult(ctx, messageResult{Message: "Login flow generated sucessfully."})
 	}
 
 	return updateLoginFlowTool, updateLoginFlowToolImpl
 }
 
-func convertToStringSlice(input interface{}) []string {
-	inputSlice := input.([]interface{})
-	result := make([]string, len(inputSlice))
-	for i, v := range inputSlice {
-		result[i] = fmt.Sprintf("%v", v)
-	}
-	return result
+// applicationSummary is an application as listed by the tools.
+type applicationSummary struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	ClientID   string `json:"client_id,omitempty"`
+	TemplateID string `json:"template_id,omitempty"`
 }
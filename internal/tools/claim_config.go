@@ -0,0 +1,309 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/asgardeo/mcp/internal/utils"
+)
+
+// Claim dialects of the claim configuration of an application. With the local dialect the
+// application receives the local claim URIs; with the custom dialect it receives the aliases
+// mapped to them.
+const (
+	claimDialectLocal  = "LOCAL"
+	claimDialectCustom = "CUSTOM"
+)
+
+// Modes of update_application_claim_config.
+const (
+	claimModeReplace = "replace"
+	claimModeAdd     = "add"
+	claimModeRemove  = "remove"
+)
+
+// claimArgument is a claim of the update_application_claim_config tool, given either as its URI
+// or as an object. Unset flags keep the current value in the add mode and take the default otherwise.
+type claimArgument struct {
+	URI       string `json:"uri"`
+	Mandatory *bool  `json:"mandatory"`
+	Requested *bool  `json:"requested"`
+	Alias     string `json:"alias"`
+}
+
+func (c *claimArgument) UnmarshalJSON(data []byte) error {
+	var uri string
+	if err := json.Unmarshal(data, &uri); err == nil {
+		// Simplified form: only the claim URI provided.
+		*c = claimArgument{URI: uri}
+		return nil
+	}
+	type plain claimArgument
+	return json.Unmarshal(data, (*plain)(c))
+}
+
+// applicationClaim is a local claim in the claim configuration of an application.
+type applicationClaim struct {
+	URI string `json:"uri"`
+	// Alias is the name the application receives the claim as, with the custom dialect.
+	Alias string `json:"alias,omitempty"`
+	// Requested claims are asked from the user; a claim that is only mapped keeps its alias.
+	Requested bool `json:"requested"`
+	Mandatory bool `json:"mandatory"`
+}
+
+// claimConfig is the claim configuration of an application with the subject and role claims
+// as local claim URIs, whatever the dialect.
+type claimConfig struct {
+	Claims                  []applicationClaim `json:"claims"`
+	SubjectClaim            string             `json:"subject_claim,omitempty"`
+	SubjectIncludeTenant    bool               `json:"subject_include_tenant_domain"`
+	SubjectIncludeUserstore bool               `json:"subject_include_userstore_domain"`
+	RoleClaim               string             `json:"role_claim,omitempty"`
+	Dialect                 string             `json:"dialect"`
+	subject, role           map[string]interface{}
+}
+
+// parseClaimConfig reads the claimConfiguration of an application.
+func parseClaimConfig(cfg map[string]interface{}) *claimConfig {
+	config := &claimConfig{Dialect: claimDialectLocal}
+	if dialect, _ := cfg["dialect"].(string); dialect == claimDialectCustom {
+		config.Dialect = claimDialectCustom
+	}
+
+	// With the custom dialect the requested claims, the subject and the role claim name the aliases.
+	localURIs := map[string]string{}
+	if config.Dialect == claimDialectCustom {
+		mappings, _ := cfg["claimMappings"].([]interface{})
+		for _, item := range mappings {
+			mapping, _ := item.(map[string]interface{})
+			alias, _ := mapping["applicationClaim"].(string)
+			uri, _ := nested(mapping, "localClaim", "uri").(string)
+			if uri == "" {
+				continue
+			}
+			claim := applicationClaim{URI: uri}
+			if alias != uri {
+				claim.Alias = alias
+			}
+			localURIs[alias] = uri
+			config.Claims = append(config.Claims, claim)
+		}
+	}
+	localURI := func(uri string) string {
+		if local, ok := localURIs[uri]; ok {
+			return local
+		}
+		return uri
+	}
+
+	requestedClaims, _ := cfg["requestedClaims"].([]interface{})
+	for _, item := range requestedClaims {
+		requested, _ := item.(map[string]interface{})
+		uri, _ := nested(requested, "claim", "uri").(string)
+		if uri == "" {
+			continue
+		}
+		mandatory, _ := requested["mandatory"].(bool)
+		claim := config.find(localURI(uri))
+		if claim == nil {
+			config.Claims = append(config.Claims, applicationClaim{URI: localURI(uri)})
+			claim = &config.Claims[len(config.Claims)-1]
+		}
+		claim.Requested, claim.Mandatory = true, mandatory
+	}
+
+	config.subject, _ = cfg["subject"].(map[string]interface{})
+	if config.subject == nil {
+		config.subject = map[string]interface{}{}
+	}
+	if uri, _ := nested(config.subject, "claim", "uri").(string); uri != "" {
+		config.SubjectClaim = localURI(uri)
+	}
+	config.SubjectIncludeTenant, _ = config.subject["includeTenantDomain"].(bool)
+	config.SubjectIncludeUserstore, _ = config.subject["includeUserDomain"].(bool)
+	config.role, _ = cfg["role"].(map[string]interface{})
+	if config.role == nil {
+		config.role = map[string]interface{}{}
+	}
+	if uri, _ := nested(config.role, "claim", "uri").(string); uri != "" {
+		config.RoleClaim = localURI(uri)
+	}
+	return config
+}
+
+// find returns the claim with the local URI, or nil.
+func (c *claimConfig) find(uri string) *applicationClaim {
+	for i := range c.Claims {
+		if c.Claims[i].URI == uri {
+			return &c.Claims[i]
+		}
+	}
+	return nil
+}
+
+// applyClaims changes the claims according to the mode.
+func (c *claimConfig) applyClaims(mode string, claims []claimArgument) {
+	switch mode {
+	case claimModeReplace:
+		c.Claims = make([]applicationClaim, 0, len(claims))
+		for _, arg := range claims {
+			claim := applicationClaim{URI: arg.URI, Alias: arg.Alias, Requested: true}
+			arg.applyFlags(&claim)
+			c.Claims = append(c.Claims, claim)
+		}
+	case claimModeAdd:
+		for _, arg := range claims {
+			claim := c.find(arg.URI)
+			if claim == nil {
+				c.Claims = append(c.Claims, applicationClaim{URI: arg.URI, Requested: true})
+				claim = &c.Claims[len(c.Claims)-1]
+			}
+			if arg.Alias != "" {
+				claim.Alias = arg.Alias
+			}
+			arg.applyFlags(claim)
+		}
+	case claimModeRemove:
+		removed := map[string]bool{}
+		for _, arg := range claims {
+			removed[arg.URI] = true
+		}
+		kept := make([]applicationClaim, 0, len(c.Claims))
+		for _, claim := range c.Claims {
+			if !removed[claim.URI] {
+				kept = append(kept, claim)
+			}
+		}
+		c.Claims = kept
+	}
+}
+
+func (arg claimArgument) applyFlags(claim *applicationClaim) {
+	if arg.Mandatory != nil {
+		claim.Mandatory = *arg.Mandatory
+		// A claim made mandatory is requested, unless requested says otherwise, which problems reports.
+		claim.Requested = claim.Requested || claim.Mandatory
+	}
+	if arg.Requested != nil {
+		claim.Requested = *arg.Requested
+		if !claim.Requested && arg.Mandatory == nil {
+			claim.Mandatory = false
+		}
+	}
+}
+
+// problems returns the settings that the server would reject, reported against the arguments.
+func (c *claimConfig) problems() []utils.FieldError {
+	var problems []utils.FieldError
+	seenURIs, seenAliases := map[string]bool{}, map[string]string{}
+	for _, claim := range c.Claims {
+		if seenURIs[claim.URI] {
+			problems = append(problems, utils.FieldError{Field: "claims", Message: fmt.Sprintf("%s is given more than once", claim.URI)})
+		}
+		seenURIs[claim.URI] = true
+		if claim.Mandatory && !claim.Requested {
+			problems = append(problems, utils.FieldError{Field: "claims", Message: fmt.Sprintf("%s is mandatory, so it must be requested", claim.URI)})
+		}
+		if claim.Alias == "" {
+			continue
+		}
+		if other, ok := seenAliases[claim.Alias]; ok {
+			problems = append(problems, utils.FieldError{Field: "claims", Message: fmt.Sprintf("%s is the alias of both %s and %s", claim.Alias, other, claim.URI)})
+		}
+		seenAliases[claim.Alias] = claim.URI
+	}
+	if c.dialect() == claimDialectCustom {
+		if c.SubjectClaim != "" && c.find(c.SubjectClaim) == nil {
+			problems = append(problems, utils.FieldError{Field: "subject_claim",
+				Message: fmt.Sprintf("%s must be one of the claims when aliases are used", c.SubjectClaim)})
+		}
+		if c.RoleClaim != "" && c.find(c.RoleClaim) == nil {
+			problems = append(problems, utils.FieldError{Field: "role_claim",
+				Message: fmt.Sprintf("%s must be one of the claims when aliases are used", c.RoleClaim)})
+		}
+	}
+	return problems
+}
+
+// dialect returns the custom dialect when a claim has an alias, and the local dialect otherwise.
+func (c *claimConfig) dialect() string {
+	for _, claim := range c.Claims {
+		if claim.Alias != "" {
+			return claimDialectCustom
+		}
+	}
+	return claimDialectLocal
+}
+
+// toAPI returns the claimConfiguration of the application management API. With the local dialect
+// claims that are not requested are left out, since only their alias would be kept.
+func (c *claimConfig) toAPI() map[string]interface{} {
+	c.Dialect = c.dialect()
+	name := func(uri string) string {
+		if claim := c.find(uri); claim != nil && c.Dialect == claimDialectCustom && claim.Alias != "" {
+			return claim.Alias
+		}
+		return uri
+	}
+
+	claimMappings := []interface{}{}
+	requestedClaims := []interface{}{}
+	for _, claim := range c.Claims {
+		if c.Dialect == claimDialectCustom {
+			claimMappings = append(claimMappings, map[string]interface{}{
+				"applicationClaim": name(claim.URI),
+				"localClaim":       map[string]interface{}{"uri": claim.URI},
+			})
+		}
+		if claim.Requested {
+			requestedClaims = append(requestedClaims, map[string]interface{}{
+				"claim":     map[string]interface{}{"uri": name(claim.URI)},
+				"mandatory": claim.Mandatory,
+			})
+		}
+	}
+	if c.Dialect == claimDialectLocal {
+		kept := make([]applicationClaim, 0, len(c.Claims))
+		for _, claim := range c.Claims {
+			if claim.Requested {
+				kept = append(kept, claim)
+			}
+		}
+		c.Claims = kept
+	}
+
+	if c.SubjectClaim != "" {
+		setNested(c.subject, name(c.SubjectClaim), "claim", "uri")
+	}
+	c.subject["includeTenantDomain"] = c.SubjectIncludeTenant
+	c.subject["includeUserDomain"] = c.SubjectIncludeUserstore
+	if c.RoleClaim != "" {
+		setNested(c.role, name(c.RoleClaim), "claim", "uri")
+	}
+	return map[string]interface{}{
+		"dialect":         c.Dialect,
+		"claimMappings":   claimMappings,
+		"requestedClaims": requestedClaims,
+		"subject":         c.subject,
+		"role":            c.role,
+	}
+}
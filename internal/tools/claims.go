@@ -26,23 +26,27 @@ import (
 	"github.com/asgardeo/go/pkg/claim"
 	"github.com/asgardeo/mcp/internal/asgardeo"
 	"github.com/asgardeo/mcp/internal/config"
-	"github.com/asgardeo/mcp/internal/utils"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func GetListClaimsTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	listClaimsTool := mcp.NewTool("list_claims",
 		mcp.WithDescription(fmt.Sprintf("List all claims in %s", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget("claims"),
 	)
 
 	listClaimsToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
 		excludeHiddenClaims := true
 		listLocalClaimParams := claim.LocalClaimListParamsModel{
 			ExcludeHiddenClaims: &excludeHiddenClaims,
@@ -50,13 +54,9 @@ func GetListClaimsTool() (mcp.Tool, server.ToolHandlerFunc) {
 		resp, err := client.Claim.ListLocalClaims(ctx, &listLocalClaimParams)
 		if err != nil {
 			log.Printf("Error listing claims: %v", err)
-			return nil, err
-		}
-		jsonData, err := utils.MarshalResponse(resp)
-		if err != nil {
-			return nil, err
+			return toolError(err)
 		}
-		return mcp.NewToolResultText(jsonData), nil
+		return toolResult(ctx, map[string]interface{}{"claims": resp})
 	}
 	return listClaimsTool, listClaimsToolImpl
 }
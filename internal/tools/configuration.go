@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func GetReloadConfigurationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	reloadConfigurationTool := mcp.NewTool("reload_configuration",
+		mcp.WithDescription(fmt.Sprintf("Reload the %s connection settings and verify that the server can be reached. Use this after fixing a \"not configured\" or \"unreachable\" error.", productName)),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+	)
+
+	reloadConfigurationToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client, err := asgardeo.Reload(ctx)
+		if err != nil {
+			return toolError(err)
+		}
+
+		response := map[string]string{
+			"status":   "connected",
+			"product":  config.GetProductName(),
+			"base_url": client.Config.BaseURL,
+		}
+		return toolResult(ctx, response)
+	}
+
+	return reloadConfigurationTool, reloadConfigurationToolImpl
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// confirmationTTL is how long a confirmation token returned by a preview stays valid.
+const confirmationTTL = 5 * time.Minute
+
+var (
+	// confirmationKey signs the confirmation tokens. It is generated at startup, so tokens do
+	// not survive a restart of the server.
+	confirmationKey = newConfirmationKey()
+
+	errConfirmationExpired  = errors.New("the confirmation token has expired; run the preview again to get a new one")
+	errConfirmationMismatch = errors.New("the confirmation token does not match this operation; the affected resources may have changed since the preview, run it again to get a new token")
+)
+
+func newConfirmationKey() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic("failed to generate the confirmation key: " + err.Error())
+	}
+	return key
+}
+
+// newConfirmationToken returns a token that confirms the action on the given subjects, e.g. the
+// profile and the IDs of the resources to delete, until it expires.
+func newConfirmationToken(action string, subjects []string) (string, time.Time) {
+	expiresAt := time.Now().Add(confirmationTTL).Truncate(time.Second)
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expiry + "." + confirmationSignature(action, subjects, expiry), expiresAt
+}
+
+// verifyConfirmationToken checks that the token was returned by a preview of the same action on
+// the same subjects and has not expired.
+func verifyConfirmationToken(token, action string, subjects []string) error {
+	expiry, signature, ok := strings.Cut(token, ".")
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if !ok || err != nil {
+		return errConfirmationMismatch
+	}
+	if !hmac.Equal([]byte(signature), []byte(confirmationSignature(action, subjects, expiry))) {
+		return errConfirmationMismatch
+	}
+	if time.Now().After(time.Unix(unix, 0)) {
+		return errConfirmationExpired
+	}
+	return nil
+}
+
+func confirmationSignature(action string, subjects []string, expiry string) string {
+	sorted := append([]string(nil), subjects...)
+	sort.Strings(sorted)
+	mac := hmac.New(sha256.New, confirmationKey)
+	mac.Write([]byte(action + "\n" + expiry + "\n" + strings.Join(sorted, "\n")))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"errors"
+
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// toolErrorResult is the body of a failed tool call.
+type toolErrorResult struct {
+	Error toolErrorDetails `json:"error"`
+}
+
+type toolErrorDetails struct {
+	asgardeo.ErrorDetails
+	Fields []utils.FieldError `json:"fields,omitempty"`
+}
+
+// toolPartialErrorResult is the body of a failed tool call that changed the organization before
+// it failed.
+type toolPartialErrorResult struct {
+	Error     toolErrorDetails `json:"error"`
+	Completed any              `json:"completed"`
+}
+
+// toolError turns an error into a tool error result that tells the model what kind of failure
+// happened and how to recover from it, instead of failing the call at the protocol level.
+func toolError(err error) (*mcp.CallToolResult, error) {
+	return errorResult(err, toolErrorResult{Error: errorDetails(err)})
+}
+
+// partialToolError turns an error that happened after the tool call changed the organization into
+// a tool error result that also reports what was completed, so that the model neither loses track
+// of the change nor repeats it.
+func partialToolError(err error, completed any) (*mcp.CallToolResult, error) {
+	return errorResult(err, toolPartialErrorResult{Error: errorDetails(err), Completed: completed})
+}
+
+func errorDetails(err error) toolErrorDetails {
+	var details toolErrorDetails
+	var argErr *utils.ArgumentError
+	if errors.As(err, &argErr) {
+		details.ErrorDetails = asgardeo.ErrorDetails{
+			Category:    asgardeo.CategoryValidation,
+			Message:     err.Error(),
+			Remediation: "Correct the listed arguments according to the input schema of the tool and retry.",
+		}
+		details.Fields = argErr.Fields
+	} else {
+		details.ErrorDetails = asgardeo.ClassifyError(err)
+	}
+	return details
+}
+
+func errorResult(err error, body any) (*mcp.CallToolResult, error) {
+	jsonData, marshalErr := utils.MarshalResponse(body)
+	if marshalErr != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	result := mcp.NewToolResultStructured(body, jsonData)
+	result.IsError = true
+	return result, nil
+}
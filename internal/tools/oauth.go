@@ -0,0 +1,323 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/asgardeo/mcp/internal/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// oidcGrantTypes maps the grant type names accepted by the tools to the grant types of the server.
+var oidcGrantTypes = map[string]string{
+	"authorization_code":  "authorization_code",
+	"implicit":            "implicit",
+	"password":            "password",
+	"client_credentials":  "client_credentials",
+	"refresh_token":       "refresh_token",
+	"token_exchange":      "urn:ietf:params:oauth:grant-type:token-exchange",
+	"device_code":         "urn:ietf:params:oauth:grant-type:device_code",
+	"saml2_bearer":        "urn:ietf:params:oauth:grant-type:saml2-bearer",
+	"jwt_bearer":          "urn:ietf:params:oauth:grant-type:jwt-bearer",
+	"organization_switch": "organization_switch",
+}
+
+// oidcGrantTypeNames lists the keys of oidcGrantTypes in the order shown to the model.
+var oidcGrantTypeNames = []string{
+	"authorization_code", "implicit", "password", "client_credentials", "refresh_token",
+	"token_exchange", "device_code", "saml2_bearer", "jwt_bearer", "organization_switch",
+}
+
+// accessTokenTypes maps the access token types accepted by the tools to those of the server.
+var accessTokenTypes = map[string]string{
+	"opaque": "Default",
+	"jwt":    "JWT",
+}
+
+// tokenBindingTypes maps the access token binding types accepted by the tools to those of the server.
+var tokenBindingTypes = map[string]string{
+	"none":           "None",
+	"cookie":         "cookie",
+	"sso_session":    "sso-session",
+	"dpop":           "DPoP",
+	"client_request": "client-request",
+	"certificate":    "certificate",
+}
+
+// oidcSettings are the OAuth/OIDC settings of an application that the tools can set. Unset
+// fields leave the current configuration untouched.
+type oidcSettings struct {
+	RedirectURLs                     []string `json:"redirect_urls"`
+	AllowedOrigins                   []string `json:"allowed_origins"`
+	GrantTypes                       []string `json:"grant_types"`
+	PublicClient                     *bool    `json:"public_client"`
+	PKCEMandatory                    *bool    `json:"pkce_mandatory"`
+	PKCESupportPlain                 *bool    `json:"pkce_support_plain"`
+	AccessTokenType                  string   `json:"access_token_type"`
+	AccessTokenBindingType           string   `json:"access_token_binding_type"`
+	UserAccessTokenExpiryTime        *int64   `json:"user_access_token_expiry_time"`
+	ApplicationAccessTokenExpiryTime *int64   `json:"application_access_token_expiry_time"`
+	AccessTokenAttributes            []string `json:"access_token_attributes"`
+	RevokeTokensWhenIDPSessionEnds   *bool    `json:"revoke_tokens_when_idp_session_terminated"`
+	RefreshTokenExpiryTime           *int64   `json:"refresh_token_expiry_time"`
+	RefreshTokenRotation             *bool    `json:"refresh_token_rotation"`
+	IDTokenEncryption                *bool    `json:"id_token_encryption"`
+	IDTokenEncryptionAlgorithm       string   `json:"id_token_encryption_algorithm"`
+	IDTokenEncryptionMethod          string   `json:"id_token_encryption_method"`
+	BackChannelLogoutURL             *string  `json:"back_channel_logout_url"`
+	ValidateRequestObjectSignature   *bool    `json:"validate_request_object_signature"`
+	RequestObjectSigningAlgorithm    string   `json:"request_object_signing_algorithm"`
+}
+
+// withOIDCSettings adds the arguments of oidcSettings to a tool.
+func withOIDCSettings() mcp.ToolOption {
+	stringTypeSchema := map[string]interface{}{"type": "string"}
+
+	return func(tool *mcp.Tool) {
+		for _, option := range []mcp.ToolOption{
+			mcp.WithArray("redirect_urls", mcp.Description("Redirect URLs of the application"), mcp.Items(stringTypeSchema)),
+			mcp.WithArray("allowed_origins", mcp.Description("Allowed origins for CORS"), mcp.Items(stringTypeSchema)),
+			mcp.WithArray("grant_types",
+				mcp.Description("Grant types the application may use. Replaces the current grant types."),
+				mcp.Items(map[string]interface{}{"type": "string", "enum": oidcGrantTypeNames}),
+			),
+			mcp.WithBoolean("public_client", mcp.Description("Whether the application is a public client that does not authenticate with a client secret, eg: a SPA or mobile app")),
+			mcp.WithBoolean("pkce_mandatory", mcp.Description("Require PKCE for the authorization code grant")),
+			mcp.WithBoolean("pkce_support_plain", mcp.Description("Accept the plain PKCE code challenge method in addition to S256")),
+			mcp.WithString("access_token_type", mcp.Description("Type of the access tokens: jwt or opaque"), mcp.Enum("jwt", "opaque")),
+			mcp.WithString("access_token_binding_type",
+				mcp.Description("Binds the access tokens to the client: none, cookie, sso_session, dpop, client_request or certificate"),
+				mcp.Enum("none", "cookie", "sso_session", "dpop", "client_request", "certificate"),
+			),
+			mcp.WithNumber("user_access_token_expiry_time", mcp.Description("Expiry time in seconds of the access token issued on behalf of the user"), mcp.Min(1)),
+			mcp.WithNumber("application_access_token_expiry_time", mcp.Description("Expiry time in seconds of the access token issued on behalf of the application"), mcp.Min(1)),
+			mcp.WithArray("access_token_attributes", mcp.Description("User attributes included in JWT access tokens"), mcp.Items(stringTypeSchema)),
+			mcp.WithBoolean("revoke_tokens_when_idp_session_terminated", mcp.Description("Revoke the tokens when the IDP session is terminated")),
+			mcp.WithNumber("refresh_token_expiry_time", mcp.Description("Expiry time in seconds of the refresh token"), mcp.Min(1)),
+			mcp.WithBoolean("refresh_token_rotation", mcp.Description("Issue a new refresh token, and invalidate the used one, on every refresh")),
+			mcp.WithBoolean("id_token_encryption", mcp.Description("Encrypt the ID tokens with the certificate of the application")),
+			mcp.WithString("id_token_encryption_algorithm", mcp.Description("Key encryption algorithm of the ID tokens, eg: RSA-OAEP-256")),
+			mcp.WithString("id_token_encryption_method", mcp.Description("Content encryption method of the ID tokens, eg: A256GCM")),
+			mcp.WithString("back_channel_logout_url", mcp.Description("URL that receives the back-channel logout requests. An empty string removes it.")),
+			mcp.WithBoolean("validate_request_object_signature", mcp.Description("Require the request objects to be signed, and validate the signature")),
+			mcp.WithString("request_object_signing_algorithm", mcp.Description("Algorithm the request objects must be signed with, eg: PS256")),
+		} {
+			option(tool)
+		}
+	}
+}
+
+// apply sets the given settings in an OIDC inbound protocol configuration and returns the names of
+// the arguments that were applied.
+func (s oidcSettings) apply(cfg map[string]interface{}) []string {
+	var applied []string
+	set := func(argument string, value interface{}, path ...string) {
+		setNested(cfg, value, path...)
+		applied = append(applied, argument)
+	}
+
+	if s.RedirectURLs != nil {
+		set("redirect_urls", callbackURLs(s.RedirectURLs), "callbackURLs")
+	}
+	if s.AllowedOrigins != nil {
+		set("allowed_origins", s.AllowedOrigins, "allowedOrigins")
+	}
+	if s.GrantTypes != nil {
+		grantTypes := make([]string, 0, len(s.GrantTypes))
+		for _, name := range s.GrantTypes {
+			grantTypes = append(grantTypes, oidcGrantTypes[name])
+		}
+		set("grant_types", grantTypes, "grantTypes")
+	}
+	if s.PublicClient != nil {
+		set("public_client", *s.PublicClient, "publicClient")
+	}
+	if s.PKCEMandatory != nil {
+		set("pkce_mandatory", *s.PKCEMandatory, "pkce", "mandatory")
+	}
+	if s.PKCESupportPlain != nil {
+		set("pkce_support_plain", *s.PKCESupportPlain, "pkce", "supportPlainTransformAlgorithm")
+	}
+	if s.AccessTokenType != "" {
+		set("access_token_type", accessTokenTypes[s.AccessTokenType], "accessToken", "type")
+	}
+	if s.AccessTokenBindingType != "" {
+		set("access_token_binding_type", tokenBindingTypes[s.AccessTokenBindingType], "accessToken", "bindingType")
+	}
+	if s.UserAccessTokenExpiryTime != nil {
+		set("user_access_token_expiry_time", *s.UserAccessTokenExpiryTime, "accessToken", "userAccessTokenExpiryInSeconds")
+	}
+	if s.ApplicationAccessTokenExpiryTime != nil {
+		set("application_access_token_expiry_time", *s.ApplicationAccessTokenExpiryTime, "accessToken", "applicationAccessTokenExpiryInSeconds")
+	}
+	if s.AccessTokenAttributes != nil {
+		set("access_token_attributes", s.AccessTokenAttributes, "accessToken", "accessTokenAttributes")
+	}
+	if s.RevokeTokensWhenIDPSessionEnds != nil {
+		set("revoke_tokens_when_idp_session_terminated", *s.RevokeTokensWhenIDPSessionEnds, "accessToken", "revokeTokensWhenIDPSessionTerminated")
+	}
+	if s.RefreshTokenExpiryTime != nil {
+		set("refresh_token_expiry_time", *s.RefreshTokenExpiryTime, "refreshToken", "expiryInSeconds")
+	}
+	if s.RefreshTokenRotation != nil {
+		set("refresh_token_rotation", *s.RefreshTokenRotation, "refreshToken", "renewRefreshToken")
+	}
+	if s.IDTokenEncryption != nil {
+		set("id_token_encryption", *s.IDTokenEncryption, "idToken", "encryption", "enabled")
+	}
+	if s.IDTokenEncryptionAlgorithm != "" {
+		set("id_token_encryption_algorithm", s.IDTokenEncryptionAlgorithm, "idToken", "encryption", "algorithm")
+	}
+	if s.IDTokenEncryptionMethod != "" {
+		set("id_token_encryption_method", s.IDTokenEncryptionMethod, "idToken", "encryption", "method")
+	}
+	if s.BackChannelLogoutURL != nil {
+		set("back_channel_logout_url", *s.BackChannelLogoutURL, "logout", "backChannelLogoutUrl")
+	}
+	if s.ValidateRequestObjectSignature != nil {
+		set("validate_request_object_signature", *s.ValidateRequestObjectSignature, "validateRequestObjectSignature")
+	}
+	if s.RequestObjectSigningAlgorithm != "" {
+		set("request_object_signing_algorithm", s.RequestObjectSigningAlgorithm, "requestObject", "requestObjectSigningAlg")
+	}
+	return applied
+}
+
+// oidcConfigProblems returns the combinations of settings in an OIDC inbound protocol configuration
+// that the server would reject or that leave the application unusable, reported against the
+// arguments that set them.
+func oidcConfigProblems(cfg map[string]interface{}) []utils.FieldError {
+	var problems []utils.FieldError
+	grantTypes := map[string]bool{}
+	for _, grantType := range stringValues(cfg["grantTypes"]) {
+		grantTypes[grantType] = true
+	}
+
+	if publicClient, _ := cfg["publicClient"].(bool); publicClient && grantTypes["client_credentials"] {
+		problems = append(problems, utils.FieldError{Field: "public_client",
+			Message: "a public client cannot use the client_credentials grant, which authenticates with the client secret"})
+	}
+	if grantTypes["authorization_code"] || grantTypes["implicit"] {
+		if len(stringValues(cfg["callbackURLs"])) == 0 {
+			problems = append(problems, utils.FieldError{Field: "redirect_urls",
+				Message: "at least one redirect URL is required by the authorization_code and implicit grants"})
+		}
+	}
+	if grantTypes["refresh_token"] && len(grantTypes) == 1 {
+		problems = append(problems, utils.FieldError{Field: "grant_types",
+			Message: "refresh_token only renews tokens issued with another grant; add the grant that issues them"})
+	}
+	if pkce, ok := cfg["pkce"].(map[string]interface{}); ok {
+		if mandatory, _ := pkce["mandatory"].(bool); mandatory && !grantTypes["authorization_code"] && len(grantTypes) > 0 {
+			problems = append(problems, utils.FieldError{Field: "pkce_mandatory",
+				Message: "PKCE applies to the authorization_code grant, which is not enabled"})
+		}
+	}
+	if encryption, ok := nested(cfg, "idToken", "encryption").(map[string]interface{}); ok {
+		if enabled, _ := encryption["enabled"].(bool); enabled {
+			if algorithm, _ := encryption["algorithm"].(string); algorithm == "" {
+				problems = append(problems, utils.FieldError{Field: "id_token_encryption_algorithm", Message: "is required to encrypt the ID tokens"})
+			}
+			if method, _ := encryption["method"].(string); method == "" {
+				problems = append(problems, utils.FieldError{Field: "id_token_encryption_method", Message: "is required to encrypt the ID tokens"})
+			}
+		}
+	}
+	if logoutURL, _ := nested(cfg, "logout", "backChannelLogoutUrl").(string); logoutURL != "" &&
+		!strings.HasPrefix(logoutURL, "https://") && !strings.HasPrefix(logoutURL, "http://") {
+		problems = append(problems, utils.FieldError{Field: "back_channel_logout_url", Message: "must be an http(s) URL"})
+	}
+	return problems
+}
+
+// newProblems returns the problems that are not in the list of the problems found before.
+func newProblems(before, after []utils.FieldError) error {
+	var problems []utils.FieldError
+	for _, problem := range after {
+		found := false
+		for _, existing := range before {
+			found = found || existing == problem
+		}
+		if !found {
+			problems = append(problems, problem)
+		}
+	}
+	if len(problems) > 0 {
+		return &utils.ArgumentError{Fields: problems}
+	}
+	return nil
+}
+
+// callbackURLs returns the callback URL configuration of the redirect URLs. Several URLs are
+// combined into one regexp=(...) pattern, which is how the server accepts more than one. Each URL
+// is quoted, so that the pattern matches exactly the given URLs.
+func callbackURLs(redirectURLs []string) []string {
+	if len(redirectURLs) <= 1 {
+		return redirectURLs
+	}
+	patterns := make([]string, len(redirectURLs))
+	for i, redirectURL := range redirectURLs {
+		patterns[i] = regexp.QuoteMeta(redirectURL)
+	}
+	return []string{"regexp=(" + strings.Join(patterns, "|") + ")"}
+}
+
+// setNested sets the value at the path of nested objects, creating the missing objects.
+func setNested(cfg map[string]interface{}, value interface{}, path ...string) {
+	for _, key := range path[:len(path)-1] {
+		child, ok := cfg[key].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			cfg[key] = child
+		}
+		cfg = child
+	}
+	cfg[path[len(path)-1]] = value
+}
+
+// nested returns the value at the path of nested objects, or nil.
+func nested(cfg map[string]interface{}, path ...string) interface{} {
+	var value interface{} = cfg
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+func stringValues(value interface{}) []string {
+	switch values := value.(type) {
+	case []string:
+		return values
+	case []interface{}:
+		result := make([]string, 0, len(values))
+		for _, item := range values {
+			if s, ok := item.(string); ok && s != "" {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"context"
+	"sync"
+
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/asgardeo/mcp/internal/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	outputFormatArgument = "output_format"
+	fieldsArgument       = "fields"
+	maxItemsArgument     = "max_items"
+	maxBytesArgument     = "max_bytes"
+	cursorArgument       = "cursor"
+)
+
+const (
+	outputFormatJSON     = "json"
+	outputFormatMarkdown = "markdown"
+)
+
+// outputOptions are the arguments that shape the result of a tool call. The budget applies only
+// to the tools declared with withResponseBudget.
+type outputOptions struct {
+	Format     string   `json:"output_format"`
+	Fields     []string `json:"fields"`
+	MaxItems   int      `json:"max_items"`
+	MaxBytes   int      `json:"max_bytes"`
+	Cursor     string   `json:"cursor"`
+	Budgeted   bool     `json:"-"`
+	Collection string   `json:"-"`
+}
+
+type outputOptionsKey struct{}
+
+var (
+	// outputTool declares the output arguments of every tool, so that they are validated for all tool calls.
+	outputTool = mcp.NewTool("output", withOutputFormat())
+	// budgetedOutputTool also declares the response budget of the tools declared with withResponseBudget.
+	budgetedOutputTool = mcp.NewTool("output", withOutputFormat(), withBudgetArguments())
+)
+
+// budgetedTools maps the name of each tool declared with withResponseBudget to the field of its
+// result that holds the listed items, empty for get tools.
+var budgetedTools sync.Map
+
+// withOutputFormat adds the optional output format selector to the input schema of the tool.
+func withOutputFormat() mcp.ToolOption {
+	return mcp.WithString(outputFormatArgument,
+		mcp.Enum(outputFormatJSON, outputFormatMarkdown),
+		mcp.DefaultString(outputFormatJSON),
+		mcp.Description("Format of the text result: json (default) or markdown, which renders lists as tables for display in chat."),
+	)
+}
+
+// withResponseBudget adds the field selector and the size limits of list and get tools, and applies
+// them to the results of the tool. The items of a list tool are in the collection field of its
+// result; a get tool has no collection and is limited as a whole.
+func withResponseBudget(collection string) mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		budgetedTools.Store(tool.Name, collection)
+		withBudgetArguments()(tool)
+	}
+}
+
+// withBudgetArguments adds the field selector and the size limits to the input schema of the tool.
+func withBudgetArguments() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithArray(fieldsArgument,
+			mcp.Items(map[string]interface{}{"type": "string"}),
+			mcp.Description("Fields to return for each listed item, or for the result of a get tool. Use dots for nested fields, e.g. [\"id\", \"name\"]. Returns every field when omitted."),
+		)(tool)
+		mcp.WithNumber(maxItemsArgument,
+			mcp.Min(1),
+			mcp.Description("Maximum number of items to return. The result is truncated with a cursor to the next items when there are more."),
+		)(tool)
+		mcp.WithNumber(maxBytesArgument,
+			mcp.Min(1),
+			mcp.DefaultNumber(config.DEFAULT_MAX_RESPONSE_BYTES),
+			mcp.Description("Maximum size of the JSON result in bytes. Items that do not fit are left out and can be fetched with the returned cursor; fields of a get result that do not fit are left out and listed."),
+		)(tool)
+		mcp.WithString(cursorArgument,
+			mcp.Description("The next_cursor of a truncated result, to continue where it stopped. Repeat the other arguments of the previous call."),
+		)(tool)
+	}
+}
+
+// WithOutputFormatArgument adds the optional output format selector to the input schema of the tool.
+func WithOutputFormatArgument(tool mcp.Tool) mcp.Tool {
+	withOutputFormat()(&tool)
+	return tool
+}
+
+// OutputMiddleware validates the output arguments of the call and keeps them for toolResult.
+func OutputMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var options outputOptions
+		tool := outputTool
+		if collection, ok := budgetedTools.Load(req.Params.Name); ok {
+			tool = budgetedOutputTool
+			options.Budgeted = true
+			options.Collection = collection.(string)
+		}
+		if err := utils.BindArguments(req, tool, &options); err != nil {
+			return toolError(err)
+		}
+		return next(context.WithValue(ctx, outputOptionsKey{}, options), req)
+	}
+}
+
+// toolResult returns the result of a successful tool call. The value, a struct or a map, is cut
+// down to the requested fields and size when the tool has a response budget, then returned as
+// structured content and rendered as JSON or markdown text in the requested format.
+func toolResult(ctx context.Context, value any) (*mcp.CallToolResult, error) {
+	options, _ := ctx.Value(outputOptionsKey{}).(outputOptions)
+	budget := utils.Budget{}
+	if options.Budgeted {
+		budget = utils.Budget{
+			Collection: options.Collection,
+			Fields:     options.Fields,
+			MaxItems:   options.MaxItems,
+			MaxBytes:   options.MaxBytes,
+			Cursor:     options.Cursor,
+		}
+	}
+	shaped, err := utils.ApplyBudget(value, budget)
+	if err != nil {
+		return toolError(err)
+	}
+
+	var text string
+	if options.Format == outputFormatMarkdown {
+		text, err = utils.RenderMarkdown(shaped)
+	} else {
+		text, err = utils.MarshalResponse(shaped)
+	}
+	if err != nil {
+		return toolError(err)
+	}
+	return mcp.NewToolResultStructured(shaped, text), nil
+}
+
+// messageResult is the result of a tool call that only reports its outcome.
+type messageResult struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"context"
+
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/asgardeo/mcp/internal/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const profileArgument = "profile"
+
+// WithProfileArgument adds the optional profile selector to the input schema of the tool.
+func WithProfileArgument(tool mcp.Tool) mcp.Tool {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties[profileArgument] = map[string]any{
+		"type":        "string",
+		"description": "Name of the profile (organization) to run the tool against. Defaults to the session default profile. Use list_profiles to see the available profiles.",
+	}
+	return tool
+}
+
+// ProfileMiddleware selects the profile named in the tool arguments for the rest of the call.
+func ProfileMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if profile, ok := req.GetArguments()[profileArgument].(string); ok && profile != "" {
+			ctx = asgardeo.WithProfile(ctx, profile)
+		}
+		return next(ctx, req)
+	}
+}
+
+func GetListProfilesTool() (mcp.Tool, server.ToolHandlerFunc) {
+	listProfilesTool := mcp.NewTool("list_profiles",
+		mcp.WithDescription("List the configured profiles (organizations) and show which one is used by default in this session"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		withResponseBudget("profiles"),
+	)
+
+	listProfilesToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		profiles, defaultProfile, currentProfile, err := asgardeo.Profiles(ctx)
+		if err != nil {
+			return toolError(err)
+		}
+
+		profileList := []interface{}{}
+		for _, name := range config.ProfileNames(profiles) {
+			profile := profiles[name]
+			profileList = append(profileList, map[string]interface{}{
+				"name":     profile.Name,
+				"base_url": profile.BaseURL,
+				"product":  profile.ProductName(),
+				"default":  profile.Name == defaultProfile,
+				"current":  profile.Name == currentProfile,
+			})
+		}
+
+		return toolResult(ctx, map[string]interface{}{"profiles": profileList})
+	}
+
+	return listProfilesTool, listProfilesToolImpl
+}
+
+func GetSetDefaultProfileTool() (mcp.Tool, server.ToolHandlerFunc) {
+	setDefaultProfileTool := mcp.NewTool("set_default_profile",
+		mcp.WithDescription("Set the profile (organization) used by the following tool calls of this session when they do not specify one"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithString(profileArgument,
+			mcp.Required(),
+			mcp.Description("Name of the profile. Use list_profiles to see the available profiles."),
+		),
+	)
+
+	setDefaultProfileToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Profile string `json:"profile"`
+		}
+		if err := utils.BindArguments(req, setDefaultProfileTool, &args); err != nil {
+			return toolError(err)
+		}
+		if err := asgardeo.SetSessionProfile(ctx, args.Profile); err != nil {
+			return toolError(err)
+		}
+		return toolResult(ctx, messageResult{Message: "Successfully set the default profile of the session to " + args.Profile + "."})
+	}
+
+	return setDefaultProfileTool, setDefaultProfileToolImpl
+}
@@ -0,0 +1,696 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/asgardeo/go/pkg/sdk"
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/asgardeo/mcp/internal/saml"
+	"github.com/asgardeo/mcp/internal/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// samlNameIDFormats maps the NameID formats accepted by the tools to those of the server, which
+// writes the URNs with slashes instead of colons.
+var samlNameIDFormats = map[string]string{
+	"email":       "urn/oasis/names/tc/SAML/1.1/nameid-format/emailAddress",
+	"persistent":  "urn/oasis/names/tc/SAML/2.0/nameid-format/persistent",
+	"transient":   "urn/oasis/names/tc/SAML/2.0/nameid-format/transient",
+	"unspecified": "urn/oasis/names/tc/SAML/1.1/nameid-format/unspecified",
+}
+
+// samlLogoutMethods maps the single logout methods accepted by the tools to those of the server.
+var samlLogoutMethods = map[string]string{
+	"back_channel":           "BACKCHANNEL",
+	"front_channel_redirect": "FRONTCHANNEL_HTTP_REDIRECT",
+	"front_channel_post":     "FRONTCHANNEL_HTTP_POST",
+}
+
+// samlLogoutBindings maps the bindings of single logout services in SP metadata to the logout
+// methods of the server.
+var samlLogoutBindings = map[string]string{
+	saml.BindingSOAP:         "BACKCHANNEL",
+	saml.BindingHTTPRedirect: "FRONTCHANNEL_HTTP_REDIRECT",
+	saml.BindingHTTPPost:     "FRONTCHANNEL_HTTP_POST",
+}
+
+// defaultSAMLBindings are the bindings a new SAML application accepts authentication requests with.
+var defaultSAMLBindings = []string{"HTTP_POST", "HTTP_REDIRECT"}
+
+// samlSettings are the SAML settings of an application that the tools can set. Unset fields
+// leave the current configuration untouched.
+type samlSettings struct {
+	Issuer                       string   `json:"issuer"`
+	ACSURLs                      []string `json:"acs_urls"`
+	DefaultACSURL                string   `json:"default_acs_url"`
+	NameIDFormat                 string   `json:"name_id_format"`
+	Audiences                    []string `json:"audiences"`
+	IdPInitiatedSSO              *bool    `json:"idp_initiated_sso"`
+	SingleLogoutURL              *string  `json:"single_logout_url"`
+	SingleLogoutResponseURL      string   `json:"single_logout_response_url"`
+	SingleLogoutMethod           string   `json:"single_logout_method"`
+	ResponseSigning              *bool    `json:"response_signing"`
+	SigningAlgorithm             string   `json:"signing_algorithm"`
+	DigestAlgorithm              string   `json:"digest_algorithm"`
+	AssertionEncryption          *bool    `json:"assertion_encryption"`
+	AssertionEncryptionAlgorithm string   `json:"assertion_encryption_algorithm"`
+	KeyEncryptionAlgorithm       string   `json:"key_encryption_algorithm"`
+	RequestSignatureValidation   *bool    `json:"request_signature_validation"`
+	AlwaysIncludeAttributes      *bool    `json:"always_include_attributes"`
+
+	// artifactBinding is set from SP metadata with an assertion consumer service of the artifact binding.
+	artifactBinding bool
+}
+
+// samlMetadataArguments are the arguments that give the metadata of a service provider.
+type samlMetadataArguments struct {
+	MetadataXML string `json:"metadata_xml"`
+	MetadataURL string `json:"metadata_url"`
+	Certificate string `json:"certificate"`
+}
+
+// withSAMLSettings adds the arguments of samlMetadataArguments and samlSettings to a tool.
+func withSAMLSettings() mcp.ToolOption {
+	stringTypeSchema := map[string]interface{}{"type": "string"}
+
+	return func(tool *mcp.Tool) {
+		for _, option := range []mcp.ToolOption{
+			mcp.WithString("metadata_xml", mcp.Description("SAML 2.0 metadata XML document of the service provider. The other arguments override the settings read from it.")),
+			mcp.WithString("metadata_url", mcp.Description("URL of the SAML 2.0 metadata of the service provider, as an alternative to metadata_xml")),
+			mcp.WithString("certificate", mcp.Description("PEM certificate of the service provider, to validate its request signatures and encrypt the assertions. "+
+				"Defaults to the certificate in the metadata.")),
+			mcp.WithString("issuer", mcp.Description("Issuer (entity ID) of the service provider, eg: https://sp.example.com/saml")),
+			mcp.WithArray("acs_urls", mcp.Description("Assertion consumer service URLs. Replaces the current URLs."), mcp.Items(stringTypeSchema)),
+			mcp.WithString("default_acs_url", mcp.Description("Assertion consumer service URL used when the request does not name one. Defaults to the first of acs_urls.")),
+			mcp.WithString("name_id_format", mcp.Description("Format of the NameID of the subject"), mcp.Enum("email", "persistent", "transient", "unspecified")),
+			mcp.WithArray("audiences", mcp.Description("Additional audiences of the assertions"), mcp.Items(stringTypeSchema)),
+			mcp.WithBoolean("idp_initiated_sso", mcp.Description("Allow IdP initiated single sign-on")),
+			mcp.WithString("single_logout_url", mcp.Description("URL that receives the logout requests. An empty string disables single logout.")),
+			mcp.WithString("single_logout_response_url", mcp.Description("URL that receives the logout responses. Defaults to single_logout_url.")),
+			mcp.WithString("single_logout_method", mcp.Description("How the logout requests are sent"), mcp.Enum("back_channel", "front_channel_redirect", "front_channel_post")),
+			mcp.WithBoolean("response_signing", mcp.Description("Sign the SAML responses")),
+			mcp.WithString("signing_algorithm", mcp.Description("Signature algorithm of the responses, eg: http://www.w3.org/2001/04/xmldsig-more#rsa-sha256")),
+			mcp.WithString("digest_algorithm", mcp.Description("Digest algorithm of the assertion signatures, eg: http://www.w3.org/2001/04/xmlenc#sha256")),
+			mcp.WithBoolean("assertion_encryption", mcp.Description("Encrypt the assertions with the certificate of the service provider")),
+			mcp.WithString("assertion_encryption_algorithm", mcp.Description("Encryption algorithm of the assertions, eg: http://www.w3.org/2009/xmlenc11#aes256-gcm")),
+			mcp.WithString("key_encryption_algorithm", mcp.Description("Encryption algorithm of the assertion keys, eg: http://www.w3.org/2009/xmlenc11#rsa-oaep")),
+			mcp.WithBoolean("request_signature_validation", mcp.Description("Require the authentication and logout requests to be signed, and validate the signature")),
+			mcp.WithBoolean("always_include_attributes", mcp.Description("Include the user attributes in every response, not only when the request asks for them")),
+		} {
+			option(tool)
+		}
+	}
+}
+
+// load reads the SP metadata given by the arguments, or returns nil when none is given.
+func (m samlMetadataArguments) load(ctx context.Context, client *sdk.Client) (*saml.SPMetadata, error) {
+	var data []byte
+	field := "metadata_xml"
+	switch {
+	case m.MetadataXML != "" && m.MetadataURL != "":
+		return nil, &utils.ArgumentError{Fields: []utils.FieldError{{Field: "metadata_xml, metadata_url", Message: "give only one of them"}}}
+	case m.MetadataXML != "":
+		data = []byte(m.MetadataXML)
+	case m.MetadataURL != "":
+		field = "metadata_url"
+		if !strings.HasPrefix(strings.ToLower(m.MetadataURL), "https://") {
+			return nil, &utils.ArgumentError{Fields: []utils.FieldError{{Field: field, Message: "must be an https URL"}}}
+		}
+		// The URL is chosen by the caller, so it must not lead to the network of the server.
+		httpClient := saml.PublicClient(client.Config.HTTPClient, config.GetSAMLMetadataAllowedHosts())
+		var err error
+		if data, err = saml.Fetch(ctx, httpClient, m.MetadataURL); err != nil {
+			log.Printf("Error fetching SP metadata: %v", err)
+			return nil, &utils.ArgumentError{Fields: []utils.FieldError{{Field: field, Message: err.Error()}}}
+		}
+	default:
+		return nil, nil
+	}
+
+	metadata, err := saml.ParseSPMetadata(data)
+	if err != nil {
+		return nil, &utils.ArgumentError{Fields: []utils.FieldError{{Field: field, Message: err.Error()}}}
+	}
+	return metadata, nil
+}
+
+// samlSettingsFromMetadata returns the settings described by the SP metadata, the certificate to
+// configure for the application and warnings about what could not be taken over.
+func samlSettingsFromMetadata(metadata *saml.SPMetadata) (samlSettings, string, []string) {
+	settings := samlSettings{
+		Issuer:        metadata.EntityID,
+		ACSURLs:       metadata.AssertionConsumerServiceURLs(),
+		DefaultACSURL: metadata.DefaultAssertionConsumerService(),
+	}
+	var warnings []string
+
+	for _, acs := range metadata.AssertionConsumerServices {
+		settings.artifactBinding = settings.artifactBinding || acs.Binding == saml.BindingHTTPArtifact
+	}
+	for _, format := range metadata.NameIDFormats {
+		if name := samlNameIDFormatName(format); name != "" {
+			settings.NameIDFormat = name
+			break
+		}
+	}
+	if settings.NameIDFormat == "" && len(metadata.NameIDFormats) > 0 {
+		warnings = append(warnings, fmt.Sprintf("None of the NameID formats of the metadata is supported (%s); the default format is used.",
+			strings.Join(metadata.NameIDFormats, ", ")))
+	}
+	for _, slo := range metadata.SingleLogoutServices {
+		method, ok := samlLogoutBindings[slo.Binding]
+		if !ok {
+			continue
+		}
+		location := slo.Location
+		settings.SingleLogoutURL = &location
+		settings.SingleLogoutResponseURL = slo.ResponseLocation
+		settings.SingleLogoutMethod = samlLogoutMethodName(method)
+		break
+	}
+	enabled := true
+	if metadata.AuthnRequestsSigned {
+		settings.RequestSignatureValidation = &enabled
+	}
+	if metadata.WantAssertionsSigned {
+		settings.ResponseSigning = &enabled
+	}
+
+	var certificate string
+	switch {
+	case len(metadata.SigningCertificates) > 0:
+		certificate = metadata.SigningCertificates[0]
+		if len(metadata.EncryptionCertificates) > 0 && metadata.EncryptionCertificates[0] != certificate {
+			warnings = append(warnings, "The metadata has separate signing and encryption certificates; the signing certificate is configured, "+
+				"so assertion encryption with the encryption certificate is not possible.")
+		}
+	case len(metadata.EncryptionCertificates) > 0:
+		certificate = metadata.EncryptionCertificates[0]
+	}
+	if len(metadata.SigningCertificates) > 1 {
+		warnings = append(warnings, "The metadata has more than one signing certificate; only the first is configured.")
+	}
+	return settings, certificate, warnings
+}
+
+// apply sets the given settings in the manualConfiguration of a SAML inbound protocol
+// configuration and returns the names of the arguments that were applied.
+func (s samlSettings) apply(cfg map[string]interface{}) []string {
+	var applied []string
+	set := func(argument string, value interface{}, path ...string) {
+		setNested(cfg, value, path...)
+		applied = append(applied, argument)
+	}
+
+	if s.Issuer != "" {
+		set("issuer", s.Issuer, "issuer")
+	}
+	if s.ACSURLs != nil {
+		set("acs_urls", s.ACSURLs, "assertionConsumerUrls")
+		// The default must be one of the URLs, so a default that was removed moves to the first URL.
+		if current, _ := cfg["defaultAssertionConsumerUrl"].(string); s.DefaultACSURL == "" && !slices.Contains(s.ACSURLs, current) && len(s.ACSURLs) > 0 {
+			cfg["defaultAssertionConsumerUrl"] = s.ACSURLs[0]
+		}
+	}
+	if s.DefaultACSURL != "" {
+		set("default_acs_url", s.DefaultACSURL, "defaultAssertionConsumerUrl")
+	}
+	if s.artifactBinding {
+		bindings := stringValues(nested(cfg, "singleSignOnProfile", "bindings"))
+		if !slices.Contains(bindings, "ARTIFACT") {
+			setNested(cfg, append(slices.Clone(bindings), "ARTIFACT"), "singleSignOnProfile", "bindings")
+		}
+	}
+	if s.NameIDFormat != "" {
+		set("name_id_format", samlNameIDFormats[s.NameIDFormat], "singleSignOnProfile", "assertion", "nameIdFormat")
+	}
+	if s.Audiences != nil {
+		set("audiences", s.Audiences, "singleSignOnProfile", "assertion", "audiences")
+	}
+	if s.IdPInitiatedSSO != nil {
+		set("idp_initiated_sso", *s.IdPInitiatedSSO, "singleSignOnProfile", "enableIdpInitiatedSingleSignOn")
+	}
+	if s.SingleLogoutURL != nil {
+		set("single_logout_url", *s.SingleLogoutURL, "singleLogoutProfile", "logoutRequestUrl")
+		setNested(cfg, *s.SingleLogoutURL != "", "singleLogoutProfile", "enabled")
+		if s.SingleLogoutResponseURL == "" {
+			setNested(cfg, *s.SingleLogoutURL, "singleLogoutProfile", "logoutResponseUrl")
+		}
+	}
+	if s.SingleLogoutResponseURL != "" {
+		set("single_logout_response_url", s.SingleLogoutResponseURL, "singleLogoutProfile", "logoutResponseUrl")
+	}
+	if s.SingleLogoutMethod != "" {
+		set("single_logout_method", samlLogoutMethods[s.SingleLogoutMethod], "singleLogoutProfile", "logoutMethod")
+	}
+	if s.ResponseSigning != nil {
+		set("response_signing", *s.ResponseSigning, "responseSigning", "enabled")
+	}
+	if s.SigningAlgorithm != "" {
+		set("signing_algorithm", s.SigningAlgorithm, "responseSigning", "signingAlgorithm")
+	}
+	if s.DigestAlgorithm != "" {
+		set("digest_algorithm", s.DigestAlgorithm, "singleSignOnProfile", "assertion", "digestAlgorithm")
+	}
+	if s.AssertionEncryption != nil {
+		set("assertion_encryption", *s.AssertionEncryption, "singleSignOnProfile", "assertion", "encryption", "enabled")
+	}
+	if s.AssertionEncryptionAlgorithm != "" {
+		set("assertion_encryption_algorithm", s.AssertionEncryptionAlgorithm, "singleSignOnProfile", "assertion", "encryption", "assertionEncryptionAlgorithm")
+	}
+	if s.KeyEncryptionAlgorithm != "" {
+		set("key_encryption_algorithm", s.KeyEncryptionAlgorithm, "singleSignOnProfile", "assertion", "encryption", "keyEncryptionAlgorithm")
+	}
+	if s.RequestSignatureValidation != nil {
+		set("request_signature_validation", *s.RequestSignatureValidation, "requestValidation", "enableSignatureValidation")
+	}
+	if s.AlwaysIncludeAttributes != nil {
+		set("always_include_attributes", *s.AlwaysIncludeAttributes, "attributeProfile", "alwaysIncludeAttributesInResponse")
+		if *s.AlwaysIncludeAttributes {
+			setNested(cfg, true, "attributeProfile", "enabled")
+		}
+	}
+	return applied
+}
+
+// samlConfigProblems returns the settings of the manualConfiguration of a SAML inbound protocol
+// configuration that the server would reject or that leave the application unusable, reported
+// against the arguments that set them.
+func samlConfigProblems(cfg map[string]interface{}, hasCertificate bool) []utils.FieldError {
+	var problems []utils.FieldError
+	isURL := func(value string) bool {
+		return strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "http://")
+	}
+
+	if issuer, _ := cfg["issuer"].(string); issuer == "" {
+		problems = append(problems, utils.FieldError{Field: "issuer", Message: "is required; give it or the metadata of the service provider"})
+	}
+	acsURLs := stringValues(cfg["assertionConsumerUrls"])
+	if len(acsURLs) == 0 {
+		problems = append(problems, utils.FieldError{Field: "acs_urls", Message: "at least one assertion consumer service URL is required"})
+	}
+	for _, acsURL := range acsURLs {
+		if !isURL(acsURL) {
+			problems = append(problems, utils.FieldError{Field: "acs_urls", Message: fmt.Sprintf("%q is not an http(s) URL", acsURL)})
+		}
+	}
+	if defaultURL, _ := cfg["defaultAssertionConsumerUrl"].(string); len(acsURLs) > 0 && !slices.Contains(acsURLs, defaultURL) {
+		problems = append(problems, utils.FieldError{Field: "default_acs_url", Message: "must be one of acs_urls"})
+	}
+	if enabled, _ := nested(cfg, "singleLogoutProfile", "enabled").(bool); enabled {
+		if logoutURL, _ := nested(cfg, "singleLogoutProfile", "logoutRequestUrl").(string); !isURL(logoutURL) {
+			problems = append(problems, utils.FieldError{Field: "single_logout_url", Message: "must be an http(s) URL"})
+		}
+	}
+	if enabled, _ := nested(cfg, "singleSignOnProfile", "assertion", "encryption", "enabled").(bool); enabled && !hasCertificate {
+		problems = append(problems, utils.FieldError{Field: "certificate", Message: "is required to encrypt the assertions"})
+	}
+	if enabled, _ := nested(cfg, "requestValidation", "enableSignatureValidation").(bool); enabled && !hasCertificate {
+		problems = append(problems, utils.FieldError{Field: "certificate", Message: "is required to validate the request signatures"})
+	}
+	return problems
+}
+
+// samlNameIDFormatName returns the name of the NameID format given as a URN, or "" when the
+// tools do not support it.
+func samlNameIDFormatName(format string) string {
+	format = strings.ReplaceAll(format, ":", "/")
+	for name, value := range samlNameIDFormats {
+		if value == format {
+			return name
+		}
+	}
+	return ""
+}
+
+func samlLogoutMethodName(method string) string {
+	for name, value := range samlLogoutMethods {
+		if value == method {
+			return name
+		}
+	}
+	return ""
+}
+
+// samlIdentityProvider returns what a service provider needs to trust the tenant: the entity ID,
+// the endpoints and the signing certificate, read from the IdP metadata. A failure to read the
+// metadata is returned as a warning.
+func samlIdentityProvider(ctx context.Context, client *sdk.Client) (map[string]interface{}, string) {
+	idp := map[string]interface{}{"metadata_url": asgardeo.SAMLIdPMetadataURL(client)}
+	data, err := asgardeo.GetSAMLIdPMetadata(ctx, client)
+	if err == nil {
+		var metadata *saml.IdPMetadata
+		if metadata, err = saml.ParseIdPMetadata(data); err == nil {
+			idp["entity_id"] = metadata.EntityID
+			idp["single_sign_on_services"] = metadata.SingleSignOnServices
+			if len(metadata.SingleLogoutServices) > 0 {
+				idp["single_logout_services"] = metadata.SingleLogoutServices
+			}
+			if len(metadata.SigningCertificates) > 0 {
+				idp["signing_certificate"] = metadata.SigningCertificates[0]
+			}
+			return idp, ""
+		}
+	}
+	log.Printf("Error reading SAML IdP metadata: %v", err)
+	return idp, fmt.Sprintf("The IdP metadata could not be read (%v); download it from metadata_url.", err)
+}
+
+func GetCreateSAMLApplicationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	samlAppTool := mcp.NewTool("create_saml_application",
+		mcp.WithDescription(fmt.Sprintf("Create a SAML 2.0 application in %s for a service provider, from its metadata (metadata_xml or metadata_url), "+
+			"from the issuer and ACS URLs, or from the metadata with some settings overridden. "+
+			"The result includes the identity provider details the service provider needs to trust %s.", productName, productName)),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithString("application_name", mcp.Description("Name of the application"), mcp.Required()),
+		mcp.WithString("description", mcp.Description("Description of the application")),
+		withSAMLSettings(),
+	)
+
+	samlAppToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			ApplicationName string `json:"application_name"`
+			Description     string `json:"description"`
+			samlMetadataArguments
+			samlSettings
+		}
+		if err := utils.BindArguments(req, samlAppTool, &args); err != nil {
+			return toolError(err)
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+		metadata, err := args.samlMetadataArguments.load(ctx, client)
+		if err != nil {
+			return toolError(err)
+		}
+
+		samlConfig := map[string]interface{}{
+			"singleSignOnProfile": map[string]interface{}{"bindings": slices.Clone(defaultSAMLBindings)},
+		}
+		certificate := args.Certificate
+		var warnings []string
+		if metadata != nil {
+			fromMetadata, metadataCertificate, metadataWarnings := samlSettingsFromMetadata(metadata)
+			fromMetadata.apply(samlConfig)
+			if certificate == "" {
+				certificate = metadataCertificate
+			}
+			warnings = metadataWarnings
+		}
+		args.samlSettings.apply(samlConfig)
+		if problems := samlConfigProblems(samlConfig, certificate != ""); len(problems) > 0 {
+			return toolError(&utils.ArgumentError{Fields: problems})
+		}
+
+		app := map[string]interface{}{
+			"name":                         args.ApplicationName,
+			"inboundProtocolConfiguration": map[string]interface{}{"saml": map[string]interface{}{"manualConfiguration": samlConfig}},
+		}
+		if args.Description != "" {
+			app["description"] = args.Description
+		}
+		if certificate != "" {
+			app["advancedConfigurations"] = map[string]interface{}{"certificate": map[string]interface{}{"type": "PEM", "value": certificate}}
+		}
+		appID, err := asgardeo.CreateApplication(ctx, client, app)
+		if err != nil {
+			log.Printf("Error creating SAML application: %v", err)
+			return toolError(err)
+		}
+
+		appConfig := map[string]interface{}{
+			"name":            args.ApplicationName,
+			"id":              appID,
+			"issuer":          samlConfig["issuer"],
+			"acs_urls":        samlConfig["assertionConsumerUrls"],
+			"default_acs_url": samlConfig["defaultAssertionConsumerUrl"],
+		}
+		if nameIDFormat, ok := nested(samlConfig, "singleSignOnProfile", "assertion", "nameIdFormat").(string); ok {
+			appConfig["name_id_format"] = nameIDFormat
+		}
+		if certificate != "" {
+			appConfig["certificate_configured"] = true
+		}
+		idp, warning := samlIdentityProvider(ctx, client)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		response := map[string]interface{}{
+			"application_configurations": appConfig,
+			"identity_provider":          idp,
+		}
+		if len(warnings) > 0 {
+			response["warnings"] = warnings
+		}
+		return toolResult(ctx, response)
+	}
+
+	return samlAppTool, samlAppToolImpl
+}
+
+func GetUpdateSAMLApplicationTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	updateSAMLAppTool := mcp.NewTool("update_saml_application",
+		mcp.WithDescription(fmt.Sprintf("Update the SAML 2.0 configuration of an application in %s, from new metadata of the service provider "+
+			"(metadata_xml or metadata_url) or from individual settings. Only the given settings are changed; the others keep their current values. "+
+			"Identify the application by exactly one of id, name or client_id, where client_id is the issuer of the service provider.", productName)),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		withApplicationReference(),
+		withSAMLSettings(),
+	)
+
+	updateSAMLAppToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			applicationReference
+			samlMetadataArguments
+			samlSettings
+		}
+		if err := utils.BindArguments(req, updateSAMLAppTool, &args); err != nil {
+			return toolError(err)
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+		appID, err := args.applicationReference.resolve(ctx, client)
+		if err != nil {
+			return toolError(err)
+		}
+		metadata, err := args.samlMetadataArguments.load(ctx, client)
+		if err != nil {
+			return toolError(err)
+		}
+
+		app, err := asgardeo.GetApplication(ctx, client, appID)
+		if err != nil {
+			log.Printf("Error retrieving application: %v", err)
+			return toolError(err)
+		}
+		samlConfig, err := asgardeo.GetInboundProtocol(ctx, client, appID, "saml")
+		if err != nil {
+			log.Printf("Error retrieving SAML configuration of application: %v", err)
+			return toolError(err)
+		}
+		if manual, ok := samlConfig["manualConfiguration"].(map[string]interface{}); ok {
+			samlConfig = manual
+		}
+		currentCertificate, _ := nested(app, "advancedConfigurations", "certificate", "value").(string)
+		// Problems that the configuration already had are left for the user to fix separately.
+		existingProblems := samlConfigProblems(samlConfig, currentCertificate != "")
+
+		var applied, warnings []string
+		certificate := args.Certificate
+		if metadata != nil {
+			fromMetadata, metadataCertificate, metadataWarnings := samlSettingsFromMetadata(metadata)
+			if len(fromMetadata.apply(samlConfig)) > 0 {
+				applied = append(applied, "metadata")
+			}
+			if certificate == "" {
+				certificate = metadataCertificate
+			}
+			warnings = metadataWarnings
+		}
+		applied = append(applied, args.samlSettings.apply(samlConfig)...)
+		if certificate != "" && certificate != currentCertificate {
+			applied = append(applied, "certificate")
+		}
+		if len(applied) == 0 {
+			return toolResult(ctx, messageResult{Message: "No SAML settings were given; the application was not changed."})
+		}
+		if err := newProblems(existingProblems, samlConfigProblems(samlConfig, currentCertificate != "" || certificate != "")); err != nil {
+			return toolError(err)
+		}
+
+		// The certificate goes first, since the SAML configuration may need it for encryption or
+		// signature validation.
+		certificateChanged := certificate != "" && certificate != currentCertificate
+		if certificateChanged {
+			if err := asgardeo.PatchApplication(ctx, client, appID, certificatePatch(certificate)); err != nil {
+				log.Printf("Error updating application certificate: %v", err)
+				return toolError(err)
+			}
+		}
+		if err := asgardeo.UpdateInboundProtocol(ctx, client, appID, "saml", map[string]interface{}{"manualConfiguration": samlConfig}); err != nil {
+			log.Printf("Error updating application: %v", err)
+			if !certificateChanged {
+				return toolError(err)
+			}
+			return certificateRollback(ctx, client, appID, currentCertificate, err)
+		}
+
+		message := "Successfully updated the application: " + strings.Join(applied, ", ") + "."
+		if len(warnings) > 0 {
+			return toolResult(ctx, map[string]interface{}{"message": message, "warnings": warnings})
+		}
+		return toolResult(ctx, messageResult{Message: message})
+	}
+
+	return updateSAMLAppTool, updateSAMLAppToolImpl
+}
+
+func certificatePatch(certificate string) map[string]interface{} {
+	return map[string]interface{}{"advancedConfigurations": map[string]interface{}{"certificate": map[string]interface{}{"type": "PEM", "value": certificate}}}
+}
+
+// certificateRollback restores the previous certificate of an application after its SAML
+// configuration could not be updated, and reports the certificate as changed when that fails too.
+func certificateRollback(ctx context.Context, client *sdk.Client, appID, previous string, updateErr error) (*mcp.CallToolResult, error) {
+	message := "The certificate of the application was replaced, but its SAML configuration could not be updated"
+	if previous == "" {
+		// An application without a certificate cannot be given an empty one again.
+		return partialToolError(updateErr, map[string]interface{}{
+			"message": message + ". The new certificate was kept; retry the update to apply the SAML settings.",
+			"applied": []string{"certificate"},
+		})
+	}
+	if err := asgardeo.PatchApplication(ctx, client, appID, certificatePatch(previous)); err != nil {
+		log.Printf("Error restoring application certificate: %v", err)
+		return partialToolError(updateErr, map[string]interface{}{
+			"message": message + " and the previous certificate could not be restored: " + err.Error() +
+				". Retry the update to apply the SAML settings.",
+			"applied": []string{"certificate"},
+		})
+	}
+	return toolError(updateErr)
+}
+
+func GetSAMLIdPMetadataTool() (mcp.Tool, server.ToolHandlerFunc) {
+	productName := config.GetProductName()
+
+	idpMetadataTool := mcp.NewTool("get_saml_idp_metadata",
+		mcp.WithDescription(fmt.Sprintf("Download the SAML 2.0 identity provider metadata of the %s tenant, which service providers import to trust it. "+
+			"Returns the entity ID, the endpoints and the signing certificate, and the XML document or the file it was written to.", productName)),
+		// Not read-only, since the metadata can be written to a file on the server.
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithString("output_file", mcp.Description("Path of a new file, relative to the working directory of the server, to write the metadata to instead of returning it. An existing file is not overwritten")),
+	)
+
+	idpMetadataToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			OutputFile string `json:"output_file"`
+		}
+		if err := utils.BindArguments(req, idpMetadataTool, &args); err != nil {
+			return toolError(err)
+		}
+		var outputPath string
+		if args.OutputFile != "" {
+			workDir, err := os.Getwd()
+			if err != nil {
+				return toolError(fmt.Errorf("failed to read the working directory: %w", err))
+			}
+			if outputPath, err = resolveLocalPath("output_file", workDir, args.OutputFile); err != nil {
+				return toolError(err)
+			}
+			if _, err := os.Lstat(outputPath); err == nil {
+				return toolError(&utils.ArgumentError{Fields: []utils.FieldError{{Field: "output_file", Message: "already exists; choose a new file name"}}})
+			}
+		}
+
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+		data, err := asgardeo.GetSAMLIdPMetadata(ctx, client)
+		if err != nil {
+			log.Printf("Error downloading SAML IdP metadata: %v", err)
+			return toolError(err)
+		}
+
+		response := map[string]interface{}{"metadata_url": asgardeo.SAMLIdPMetadataURL(client)}
+		if metadata, err := saml.ParseIdPMetadata(data); err != nil {
+			response["warnings"] = []string{fmt.Sprintf("The metadata could not be read: %v", err)}
+		} else {
+			response["identity_provider"] = metadata
+		}
+		if outputPath != "" {
+			if err := writeMetadataFile(outputPath, data); err != nil {
+				return toolError(err)
+			}
+			response["output_file"] = outputPath
+		} else {
+			response["metadata_xml"] = string(data)
+		}
+		return toolResult(ctx, response)
+	}
+
+	return idpMetadataTool, idpMetadataToolImpl
+}
+
+// writeMetadataFile writes the metadata to a file that it creates, so that an existing file, or the target
+// of a symbolic link placed at the path in the meantime, is never overwritten.
+func writeMetadataFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, fs.ErrExist) {
+		return &utils.ArgumentError{Fields: []utils.FieldError{{Field: "output_file", Message: "already exists; choose a new file name"}}}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create the metadata file: %w", err)
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write the metadata file: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,402 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/asgardeo/mcp/internal/utils"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Secret sink related arguments of the tools that issue client secrets.
+const (
+	secretSinkArg      = "secret_sink"
+	secretFileArg      = "secret_file"
+	secretFrameworkArg = "secret_framework"
+)
+
+// dotenvFramework describes the dotenv fragment that configures an application of a framework.
+type dotenvFramework struct {
+	file      string
+	variables func(secret clientSecret) []secretValue
+}
+
+// dotenvFrameworks are the frameworks supported by the dotenv sink, with the file they read their
+// environment from and the variables their Asgardeo integration expects.
+var dotenvFrameworks = map[string]dotenvFramework{
+	"nextjs": {".env.local", func(secret clientSecret) []secretValue {
+		return []secretValue{
+			{"NEXT_PUBLIC_ASGARDEO_BASE_URL", secret.BaseURL},
+			{"NEXT_PUBLIC_ASGARDEO_CLIENT_ID", secret.ClientID},
+			{"ASGARDEO_CLIENT_SECRET", secret.Secret},
+		}
+	}},
+	"express": {".env", func(secret clientSecret) []secretValue {
+		return []secretValue{
+			{"ASGARDEO_BASE_URL", secret.BaseURL},
+			{"ASGARDEO_CLIENT_ID", secret.ClientID},
+			{"ASGARDEO_CLIENT_SECRET", secret.Secret},
+		}
+	}},
+	"spring_boot": {".env", func(secret clientSecret) []secretValue {
+		return []secretValue{
+			{"SPRING_SECURITY_OAUTH2_CLIENT_PROVIDER_ASGARDEO_ISSUER_URI", secret.Issuer},
+			{"SPRING_SECURITY_OAUTH2_CLIENT_REGISTRATION_ASGARDEO_CLIENT_ID", secret.ClientID},
+			{"SPRING_SECURITY_OAUTH2_CLIENT_REGISTRATION_ASGARDEO_CLIENT_SECRET", secret.Secret},
+		}
+	}},
+}
+
+// secretSinkArguments select where a client secret is delivered. Secrets are never part of a tool
+// result, so that they do not end up in the conversation or its logs.
+type secretSinkArguments struct {
+	Sink      string `json:"secret_sink"`
+	File      string `json:"secret_file"`
+	Framework string `json:"secret_framework"`
+}
+
+// clientSecret is a client secret to deliver, with the settings that go along with it.
+type clientSecret struct {
+	BaseURL  string
+	Issuer   string
+	ClientID string
+	Secret   string
+}
+
+// secretValue is one variable written to a secret sink.
+type secretValue struct {
+	Key   string
+	Value string
+}
+
+// secretReference tells in a tool result where a secret was delivered.
+type secretReference struct {
+	Sink      string   `json:"sink"`
+	Location  string   `json:"location,omitempty"`
+	Variables []string `json:"variables,omitempty"`
+	Note      string   `json:"note,omitempty"`
+}
+
+// withSecretSink adds the arguments that select the secret sink to a tool.
+func withSecretSink() mcp.ToolOption {
+	return func(tool *mcp.Tool) {
+		mcp.WithString(secretSinkArg,
+			mcp.Description(fmt.Sprintf("Where to deliver the client secret; it is never returned in the tool result. "+
+				"redact discards it; env writes CLIENT_ID and CLIENT_SECRET to the .env file at secret_file; "+
+				"dotenv writes the variables of secret_framework to its env file at secret_file; "+
+				"file writes a file readable only by the owner in the secrets directory of the server; "+
+				"stdout prints it on the console of the server, only with the stdio transport. Defaults to %s.", config.LoadSecretPolicy().Sink)),
+			mcp.Enum(config.SecretSinkNames()...),
+		)(tool)
+		mcp.WithString(secretFileArg,
+			mcp.Description("For env and dotenv, path of the env file to update relative to the working directory of the server, by default .env or the env file of the framework. "+
+				"For file, name of the file in the secrets directory, by default <client_id>.env."),
+		)(tool)
+		mcp.WithString(secretFrameworkArg,
+			mcp.Description("Framework of the application, for the dotenv sink."),
+			mcp.Enum("express", "nextjs", "spring_boot"),
+		)(tool)
+	}
+}
+
+// validate checks the sink arguments and fills in the default sink, so that a mistake is reported
+// before a secret is issued.
+func (s *secretSinkArguments) validate() error {
+	if s.Sink == "" {
+		s.Sink = config.LoadSecretPolicy().Sink
+	}
+	if s.Sink == config.SecretSinks.Dotenv && s.Framework == "" {
+		return &utils.ArgumentError{Fields: []utils.FieldError{{Field: secretFrameworkArg, Message: "is required for the dotenv sink"}}}
+	}
+	// Over HTTP the console of the server is a log shared by every caller.
+	if s.Sink == config.SecretSinks.Stdout && config.ActiveTransportMode() != config.TransportModes.Stdio {
+		return &utils.ArgumentError{Fields: []utils.FieldError{{Field: secretSinkArg, Message: "stdout is only available with the stdio transport; choose another sink"}}}
+	}
+	if s.File != "" && !filepath.IsLocal(s.File) {
+		switch s.Sink {
+		case config.SecretSinks.File:
+			return &utils.ArgumentError{Fields: []utils.FieldError{{Field: secretFileArg, Message: "must be a file name within the secrets directory for the file sink"}}}
+		case config.SecretSinks.Env, config.SecretSinks.Dotenv:
+			return &utils.ArgumentError{Fields: []utils.FieldError{{Field: secretFileArg, Message: "must be a relative path within the working directory of the server"}}}
+		}
+	}
+	return nil
+}
+
+// check validates the sink arguments and verifies that the sink can be written, before a tool
+// issues a secret that would otherwise be lost.
+func (s *secretSinkArguments) check() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	if err := s.probe(); err != nil {
+		var argErr *utils.ArgumentError
+		if errors.As(err, &argErr) {
+			return err
+		}
+		field := secretSinkArg
+		if s.File != "" {
+			field = secretFileArg
+		}
+		return &utils.ArgumentError{Fields: []utils.FieldError{{Field: field, Message: err.Error()}}}
+	}
+	return nil
+}
+
+// checkRotation checks the sink arguments of a tool that replaces the secret of an application.
+// The redact sink, which is the default, is refused there: it would discard the only secret that
+// still works.
+func (s *secretSinkArguments) checkRotation() error {
+	if err := s.check(); err != nil {
+		return err
+	}
+	if s.Sink == config.SecretSinks.Redact {
+		return &utils.ArgumentError{Fields: []utils.FieldError{{
+			Field:   secretSinkArg,
+			Message: "redact would discard the new secret while the previous one stops working; choose a sink that stores it, such as env, dotenv or file",
+		}}}
+	}
+	return nil
+}
+
+// probe opens the file the sink writes to, or creates a file in its directory.
+func (s *secretSinkArguments) probe() error {
+	dir, path, err := s.target("")
+	if err != nil || dir == "" {
+		return err
+	}
+	if path != "" {
+		file, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err == nil {
+			return file.Close()
+		}
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("cannot write the secret to %s: %w", path, err)
+		}
+	}
+	probe, err := os.CreateTemp(dir, ".asgardeo-mcp-*")
+	if err != nil {
+		return fmt.Errorf("cannot write the secret to %s: %w", dir, err)
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// target returns the directory of the file the sink writes the secret to and the path of the
+// file, or empty strings for the sinks that do not write files. The default file of the file
+// sink is named after the client ID; without it only the directory is returned.
+func (s secretSinkArguments) target(clientID string) (dir, path string, err error) {
+	var name string
+	switch s.Sink {
+	case config.SecretSinks.Env, config.SecretSinks.Dotenv:
+		if dir, err = os.Getwd(); err != nil {
+			return "", "", fmt.Errorf("failed to determine the working directory: %w", err)
+		}
+		name = defaultString(s.File, ".env")
+		if s.Sink == config.SecretSinks.Dotenv {
+			name = defaultString(s.File, dotenvFrameworks[s.Framework].file)
+		}
+	case config.SecretSinks.File:
+		if dir = config.LoadSecretPolicy().Dir; dir == "" {
+			return "", "", fmt.Errorf("the secrets directory is not configured; set %s", config.SECRETS_DIR_PARAM)
+		}
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return "", "", fmt.Errorf("failed to create the secrets directory: %w", err)
+		}
+		name = s.File
+		if name == "" && clientID != "" {
+			name = clientID + ".env"
+		}
+	default:
+		return "", "", nil
+	}
+	if name == "" {
+		return dir, "", nil
+	}
+	if path, err = resolveLocalPath(secretFileArg, dir, name); err != nil {
+		return "", "", err
+	}
+	return filepath.Dir(path), path, nil
+}
+
+// deliverSecret writes the secret to the selected sink and returns the reference to return
+// instead of the value.
+func deliverSecret(sink secretSinkArguments, label string, secret clientSecret) (*secretReference, error) {
+	if err := sink.validate(); err != nil {
+		return nil, err
+	}
+	generic := []secretValue{{"CLIENT_ID", secret.ClientID}, {"CLIENT_SECRET", secret.Secret}}
+	ref := &secretReference{Sink: sink.Sink}
+	_, path, err := sink.target(secret.ClientID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch sink.Sink {
+	case config.SecretSinks.Redact:
+		ref.Note = "The secret was not stored. Use regenerate_client_secret with another secret_sink to issue a new one when it is needed."
+		return ref, nil
+	case config.SecretSinks.Env:
+		return ref, upsertEnvFile(ref, path, generic)
+	case config.SecretSinks.Dotenv:
+		return ref, upsertEnvFile(ref, path, dotenvFrameworks[sink.Framework].variables(secret))
+	case config.SecretSinks.File:
+		if err := writeSecretFile(path, formatEnv(generic)); err != nil {
+			return nil, err
+		}
+		ref.Location, ref.Variables = path, secretKeys(generic)
+		return ref, nil
+	case config.SecretSinks.Stdout:
+		// Stdout carries the stdio transport, so the secret goes to stderr, which MCP hosts show
+		// in the server log rather than in the conversation.
+		ref.Location = "standard error of the server (stdout carries the stdio transport)"
+		if _, err := fmt.Fprintf(os.Stderr, "# %s\n%s", label, formatEnv(generic)); err != nil {
+			return nil, fmt.Errorf("failed to print the secret: %w", err)
+		}
+		ref.Variables = secretKeys(generic)
+		return ref, nil
+	default:
+		return nil, &utils.ArgumentError{Fields: []utils.FieldError{{
+			Field:   secretSinkArg,
+			Message: "must be one of: " + strings.Join(config.SecretSinkNames(), ", "),
+		}}}
+	}
+}
+
+// upsertEnvFile sets the variables in the env file, keeping its other lines. The file is left
+// readable only by the owner, since it now holds a secret.
+func upsertEnvFile(ref *secretReference, path string, values []secretValue) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read the env file: %w", err)
+	}
+
+	pending := map[string]string{}
+	for _, value := range values {
+		pending[value.Key] = value.Value
+	}
+	var lines []string
+	if len(data) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	}
+	for i, line := range lines {
+		key, _, ok := strings.Cut(strings.TrimPrefix(strings.TrimSpace(line), "export "), "=")
+		key = strings.TrimSpace(key)
+		if value, found := pending[key]; ok && found {
+			lines[i] = key + "=" + quoteEnvValue(value)
+			delete(pending, key)
+		}
+	}
+	for _, value := range values {
+		if _, ok := pending[value.Key]; ok {
+			lines = append(lines, value.Key+"="+quoteEnvValue(value.Value))
+		}
+	}
+
+	if err := writeSecretFile(path, strings.Join(lines, "\n")+"\n"); err != nil {
+		return err
+	}
+	ref.Location, ref.Variables = path, secretKeys(values)
+	return nil
+}
+
+// resolveLocalPath returns the path of the file named by the field argument within the base
+// directory, which must exist. The name must be local, and the path may not lead out of the
+// directory through a symbolic link, so that a tool call cannot make the server write elsewhere.
+func resolveLocalPath(field, base, name string) (string, error) {
+	if !filepath.IsLocal(name) {
+		return "", &utils.ArgumentError{Fields: []utils.FieldError{{Field: field, Message: "must be a relative path that stays within " + base}}}
+	}
+	root, err := filepath.EvalSymlinks(base)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", base, err)
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(filepath.Join(root, name)))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the directory of %s: %w", name, err)
+	}
+	if rel, err := filepath.Rel(root, dir); err != nil || !filepath.IsLocal(rel) {
+		return "", &utils.ArgumentError{Fields: []utils.FieldError{{Field: field, Message: "must not lead out of " + base}}}
+	}
+	path := filepath.Join(dir, filepath.Base(name))
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return "", &utils.ArgumentError{Fields: []utils.FieldError{{Field: field, Message: "must not be a symbolic link"}}}
+	}
+	return path, nil
+}
+
+// writeSecretFile replaces the file with the content and makes it readable only by the owner.
+func writeSecretFile(path, content string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open the secret file: %w", err)
+	}
+	defer file.Close()
+	if err := file.Chmod(0o600); err != nil {
+		return fmt.Errorf("failed to restrict the permissions of the secret file: %w", err)
+	}
+	if _, err := file.WriteString(content); err != nil {
+		return fmt.Errorf("failed to write the secret file: %w", err)
+	}
+	return file.Close()
+}
+
+func formatEnv(values []secretValue) string {
+	var content strings.Builder
+	for _, value := range values {
+		fmt.Fprintf(&content, "%s=%s\n", value.Key, quoteEnvValue(value.Value))
+	}
+	return content.String()
+}
+
+// quoteEnvValue quotes a value for an env file when dotenv parsers would otherwise misread it,
+// eg: cut it at a # or a space. Single quotes are taken literally by the parsers; a value that
+// contains one is double quoted with its special characters escaped.
+func quoteEnvValue(value string) string {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.,:/@+=%", r))
+	}) < 0 {
+		return value
+	}
+	if !strings.ContainsAny(value, "'\n\r") {
+		return "'" + value + "'"
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`, "\r", `\r`).Replace(value) + `"`
+}
+
+func secretKeys(values []secretValue) []string {
+	keys := make([]string, 0, len(values))
+	for _, value := range values {
+		keys = append(keys, value.Key)
+	}
+	return keys
+}
+
+func defaultString(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -20,23 +20,20 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 
 	"github.com/asgardeo/go/pkg/user"
 	"github.com/asgardeo/mcp/internal/asgardeo"
 	"github.com/asgardeo/mcp/internal/config"
+	"github.com/asgardeo/mcp/internal/utils"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func GetCreateUserTool() (mcp.Tool, server.ToolHandlerFunc) {
 	productName := config.GetProductName()
-	client, err := asgardeo.GetClientInstance(context.Background())
-
-	if err != nil {
-		log.Printf("Error initializing client instance: %v", err)
-	}
 
 	userCreateTool := mcp.NewTool("create_user",
 		mcp.WithDescription(fmt.Sprintf("Create a user in %s", productName)),
@@ -68,29 +65,52 @@ func GetCreateUserTool() (mcp.Tool, server.ToolHandlerFunc) {
 	)
 
 	userCreateToolImpl := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		username := req.Params.Arguments["username"].(string)
-		password := req.Params.Arguments["password"].(string)
-		email := req.Params.Arguments["email"].(string)
-		firstName := req.Params.Arguments["first_name"].(string)
-		lastName := req.Params.Arguments["last_name"].(string)
-		userstoreDomain := "DEFAULT"
-		if req.Params.Arguments["userstore_domain"] != nil {
-			userstoreDomain = req.Params.Arguments["userstore_domain"].(string)
+		client, err := asgardeo.GetClientInstance(ctx)
+		if err != nil {
+			log.Printf("Error initializing client instance: %v", err)
+			return toolError(err)
+		}
+
+		var args struct {
+			Username        string `json:"username"`
+			Password        string `json:"password"`
+			Email           string `json:"email"`
+			FirstName       string `json:"first_name"`
+			LastName        string `json:"last_name"`
+			UserstoreDomain string `json:"userstore_domain"`
+		}
+		if err := utils.BindArguments(req, userCreateTool, &args); err != nil {
+			return toolError(err)
 		}
 
 		user := user.UserCreateModel{
-			Username:  userstoreDomain + "/" + username,
-			Password:  password,
-			Email:     email,
-			FirstName: firstName,
-			LastName:  lastName,
+			Username:  args.UserstoreDomain + "/" + args.Username,
+			Password:  args.Password,
+			Email:     args.Email,
+			FirstName: args.FirstName,
+			LastName:  args.LastName,
 		}
 		resp, err := client.User.CreateUser(ctx, user)
 		if err != nil {
 			log.Printf("Error creating the user: %v", err)
-			return nil, err
+			return toolError(err)
+		}
+		defer resp.Body.Close()
+
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+			log.Printf("Error parsing the created user: %v", err)
 		}
-		return mcp.NewToolResultText(fmt.Sprintf("%+v", resp)), nil
+		return toolResult(ctx, map[string]interface{}{
+			"id":               created.ID,
+			"username":         args.Username,
+			"userstore_domain": args.UserstoreDomain,
+			"email":            args.Email,
+			"first_name":       args.FirstName,
+			"last_name":        args.LastName,
+		})
 	}
 	return userCreateTool, userCreateToolImpl
 }
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+// stdioSessionID is the ID of the single session served over stdio.
+const stdioSessionID = "stdio"
+
+// maxMessageSize limits the size of a request body. Larger requests are refused rather than truncated.
+const maxMessageSize = 10 << 20
+
+// filterLines returns a reader yielding the newline-delimited messages of r after passing them through the filter.
+func filterLines(r io.Reader, sessionID string, filter MessageFilter) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(bytes.TrimSpace(line)) > 0 {
+				line = append(filter(sessionID, bytes.TrimSpace(line)), '\n')
+				if _, writeErr := pw.Write(line); writeErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
+
+// filterRequests passes the body of POST requests through the filter. The session is identified
+// by the Mcp-Session-Id header of Streamable HTTP or the sessionId query parameter of SSE.
+func filterRequests(next http.Handler, filter MessageFilter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		sessionID := r.Header.Get("Mcp-Session-Id")
+		if sessionID == "" {
+			sessionID = r.URL.Query().Get("sessionId")
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMessageSize))
+		r.Body.Close()
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("request body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			log.Printf("Error reading request body: %v", err)
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		body = filter(sessionID, body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package transport
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/asgardeo/mcp/internal/auth"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MessageFilter may rewrite a JSON-RPC message received from a session before the MCP server handles it.
+type MessageFilter func(sessionID string, message []byte) []byte
+
+// Serve exposes the MCP server over the configured transport and blocks until
+// the transport stops or the context is cancelled. Every message received is
+// passed through the filter first.
+func Serve(ctx context.Context, s *server.MCPServer, cfg config.TransportConfig, filter MessageFilter) error {
+	if cfg.Mode == config.TransportModes.Stdio {
+		return server.NewStdioServer(s).Listen(ctx, filterLines(os.Stdin, stdioSessionID, filter), os.Stdout)
+	}
+
+	// Cancelling the base context ends long-lived streams so that shutdown
+	// does not wait on them until the timeout.
+	baseCtx, cancelStreams := context.WithCancel(context.Background())
+	defer cancelStreams()
+
+	httpServer := &http.Server{
+		Addr:        cfg.Addr,
+		BaseContext: func(_ net.Listener) context.Context { return baseCtx },
+	}
+
+	protect := func(h http.Handler) http.Handler { return filterRequests(h, filter) }
+	mux := http.NewServeMux()
+	if cfg.Auth.Enabled {
+		resource, err := auth.NewProtectedResource(cfg.Auth, cfg.BasePath)
+		if err != nil {
+			return err
+		}
+		for _, path := range resource.MetadataPaths() {
+			mux.Handle(path, resource.MetadataHandler())
+		}
+		protect = func(h http.Handler) http.Handler { return resource.Middleware(filterRequests(h, filter)) }
+	} else {
+		log.Printf("WARNING: serving without authentication as requested; anyone who can reach %s can use the management tools", cfg.Addr)
+	}
+
+	sseServer := newSSEServer(s, cfg, httpServer)
+	mux.Handle(sseServer.CompleteSsePath(), protect(sseServer))
+	mux.Handle(sseServer.CompleteMessagePath(), protect(sseServer))
+	if cfg.Mode == config.TransportModes.HTTP {
+		mux.Handle(cfg.BasePath, protect(newStreamableHTTPServer(s, cfg, httpServer)))
+	}
+	httpServer.Handler = mux
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("Serving MCP over %s on %s%s", cfg.Mode, cfg.Addr, cfg.BasePath)
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("Shutting down MCP %s transport", cfg.Mode)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	cancelStreams()
+	// Closes the SSE sessions and shuts down the shared HTTP server.
+	if err := sseServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Graceful shutdown did not complete: %v", err)
+		return httpServer.Close()
+	}
+	return nil
+}
+
+// newStreamableHTTPServer creates the Streamable HTTP handler served at the base path.
+func newStreamableHTTPServer(s *server.MCPServer, cfg config.TransportConfig, httpServer *http.Server) *server.StreamableHTTPServer {
+	opts := []server.StreamableHTTPOption{
+		server.WithStreamableHTTPServer(httpServer),
+		server.WithHeartbeatInterval(cfg.HeartbeatInterval),
+	}
+	if cfg.Stateless {
+		opts = append(opts, server.WithStateLess(true))
+	} else {
+		opts = append(opts, server.WithStateful(true))
+	}
+	return server.NewStreamableHTTPServer(s, opts...)
+}
+
+// newSSEServer creates the legacy HTTP+SSE handler served under the base path
+// for clients that do not support Streamable HTTP yet.
+func newSSEServer(s *server.MCPServer, cfg config.TransportConfig, httpServer *http.Server) *server.SSEServer {
+	opts := []server.SSEOption{
+		server.WithHTTPServer(httpServer),
+		server.WithStaticBasePath(cfg.BasePath),
+	}
+	if cfg.HeartbeatInterval > 0 {
+		opts = append(opts, server.WithKeepAliveInterval(cfg.HeartbeatInterval))
+	}
+	return server.NewSSEServer(s, opts...)
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// FieldError describes why an argument of a tool call is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ArgumentError lists every invalid argument of a tool call.
+type ArgumentError struct {
+	Fields []FieldError
+}
+
+func (e *ArgumentError) Error() string {
+	problems := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		problems[i] = field.Field + ": " + field.Message
+	}
+	return "invalid arguments: " + strings.Join(problems, "; ")
+}
+
+// BindArguments validates the arguments of the tool call against the input schema of the tool,
+// applies the defaults declared in the schema and decodes the arguments into target, a pointer
+// to a struct whose json tags name the arguments. Every problem found is returned at once as an
+// *ArgumentError, so that the model can correct all of its arguments in a single retry.
+func BindArguments(req mcp.CallToolRequest, tool mcp.Tool, target any) error {
+	args := map[string]any{}
+	for name, value := range req.GetArguments() {
+		if value != nil {
+			args[name] = value
+		}
+	}
+
+	fieldErrors := []FieldError{}
+	for name, property := range tool.InputSchema.Properties {
+		schema, _ := property.(map[string]any)
+		value, ok := args[name]
+		if !ok {
+			if defaultValue, hasDefault := schema["default"]; hasDefault {
+				args[name] = defaultValue
+			}
+			continue
+		}
+		fieldErrors = append(fieldErrors, validateValue(name, value, schema)...)
+	}
+	for _, name := range tool.InputSchema.Required {
+		if isMissing(args[name]) {
+			fieldErrors = append(fieldErrors, FieldError{Field: name, Message: "is required"})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		sort.SliceStable(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+		return &ArgumentError{Fields: fieldErrors}
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return fmt.Errorf("failed to encode arguments: %w", err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return &ArgumentError{Fields: []FieldError{{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()}}}
+		}
+		return &ArgumentError{Fields: []FieldError{{Field: "arguments", Message: err.Error()}}}
+	}
+	return nil
+}
+
+// validateValue checks a value against the type, enum, minimum, maximum, items, properties,
+// required and anyOf/oneOf keywords of its schema.
+func validateValue(field string, value any, schema map[string]any) []FieldError {
+	if alternatives, ok := schemaList(schema, "anyOf", "oneOf"); ok {
+		var nested []FieldError
+		for _, alternative := range alternatives {
+			fieldErrors := validateValue(field, value, alternative)
+			if len(fieldErrors) == 0 {
+				return nil
+			}
+			// Errors on nested fields mean that the value has the type of this alternative,
+			// which makes them more helpful than a generic mismatch.
+			if nested == nil && fieldErrors[0].Field != field {
+				nested = fieldErrors
+			}
+		}
+		if nested != nil {
+			return nested
+		}
+		return []FieldError{{Field: field, Message: "does not match any of the accepted forms"}}
+	}
+
+	schemaType, _ := schema["type"].(string)
+	switch schemaType {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return []FieldError{{Field: field, Message: "must be a string"}}
+		}
+		if enum := stringList(schema["enum"]); len(enum) > 0 && !contains(enum, s) {
+			return []FieldError{{Field: field, Message: fmt.Sprintf("must be one of %s", strings.Join(enum, ", "))}}
+		}
+	case "number", "integer":
+		n, ok := toFloat(value)
+		if !ok {
+			return []FieldError{{Field: field, Message: "must be a number"}}
+		}
+		if schemaType == "integer" && n != math.Trunc(n) {
+			return []FieldError{{Field: field, Message: "must be an integer"}}
+		}
+		if minimum, ok := toFloat(schema["minimum"]); ok && n < minimum {
+			return []FieldError{{Field: field, Message: fmt.Sprintf("must be at least %v", minimum)}}
+		}
+		if maximum, ok := toFloat(schema["maximum"]); ok && n > maximum {
+			return []FieldError{{Field: field, Message: fmt.Sprintf("must be at most %v", maximum)}}
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []FieldError{{Field: field, Message: "must be a boolean"}}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return []FieldError{{Field: field, Message: "must be an array"}}
+		}
+		itemSchema, _ := schema["items"].(map[string]any)
+		fieldErrors := []FieldError{}
+		for i, item := range items {
+			fieldErrors = append(fieldErrors, validateValue(fmt.Sprintf("%s[%d]", field, i), item, itemSchema)...)
+		}
+		return fieldErrors
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return []FieldError{{Field: field, Message: "must be an object"}}
+		}
+		fieldErrors := []FieldError{}
+		properties, _ := schema["properties"].(map[string]any)
+		for name, property := range properties {
+			propertySchema, _ := property.(map[string]any)
+			if propertyValue, ok := object[name]; ok && propertyValue != nil {
+				fieldErrors = append(fieldErrors, validateValue(field+"."+name, propertyValue, propertySchema)...)
+			}
+		}
+		for _, name := range stringList(schema["required"]) {
+			if isMissing(object[name]) {
+				fieldErrors = append(fieldErrors, FieldError{Field: field + "." + name, Message: "is required"})
+			}
+		}
+		return fieldErrors
+	}
+	return nil
+}
+
+// isMissing reports whether a required value is absent. Empty strings count as absent.
+func isMissing(value any) bool {
+	if value == nil {
+		return true
+	}
+	s, ok := value.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+func schemaList(schema map[string]any, keys ...string) ([]map[string]any, bool) {
+	for _, key := range keys {
+		raw, ok := schema[key]
+		if !ok {
+			continue
+		}
+		schemas := []map[string]any{}
+		switch list := raw.(type) {
+		case []map[string]any:
+			schemas = list
+		case []any:
+			for _, item := range list {
+				if s, ok := item.(map[string]any); ok {
+					schemas = append(schemas, s)
+				}
+			}
+		}
+		return schemas, true
+	}
+	return nil, false
+}
+
+func stringList(value any) []string {
+	switch list := value.(type) {
+	case []string:
+		return list
+	case []any:
+		strs := []string{}
+		for _, item := range list {
+			if s, ok := item.(string); ok {
+				strs = append(strs, s)
+			}
+		}
+		return strs
+	}
+	return nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
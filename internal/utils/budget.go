@@ -0,0 +1,266 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Budget limits the size of a tool result.
+type Budget struct {
+	// Collection names the top level field of the result that holds the listed items. Empty
+	// for the result of a get tool, which is limited as a whole.
+	Collection string
+	// Fields lists the fields to keep, as dot separated paths, in each item of the listed
+	// collection or in the result itself when it has no collection. Empty keeps every field.
+	Fields []string
+	// MaxItems limits the number of items returned from the collection. Zero means no limit.
+	MaxItems int
+	// MaxBytes limits the size of the JSON result. Zero means no limit.
+	MaxBytes int
+	// Cursor continues a truncated result where the previous call stopped.
+	Cursor string
+}
+
+// Truncation summarizes the items left out of a truncated result.
+type Truncation struct {
+	Collection string `json:"collection"`
+	Offset     int    `json:"offset"`
+	Returned   int    `json:"returned"`
+	Total      int    `json:"total"`
+	Omitted    int    `json:"omitted"`
+	Reason     string `json:"reason"`
+	NextCursor string `json:"next_cursor"`
+	Hint       string `json:"hint"`
+}
+
+// FieldTruncation summarizes the fields left out of a result without a collection that does not fit max_bytes.
+type FieldTruncation struct {
+	OmittedFields []string `json:"omitted_fields"`
+	Reason        string   `json:"reason"`
+	Hint          string   `json:"hint"`
+}
+
+// TruncationField is the field of a truncated result that holds its Truncation summary.
+const TruncationField = "truncation"
+
+type cursor struct {
+	Collection string `json:"collection"`
+	Offset     int    `json:"offset"`
+}
+
+// ApplyBudget projects the result onto the requested fields and cuts its collection down to the
+// item and byte limits, starting at the cursor. A truncated result gets a Truncation summary with
+// the cursor of the next items. A result without a collection that exceeds the byte limit keeps
+// the fields that fit and gets a FieldTruncation summary instead. The value is returned as
+// generic JSON.
+func ApplyBudget(value any, budget Budget) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+
+	object, ok := result.(map[string]any)
+	if !ok {
+		return result, nil
+	}
+	collection := budget.Collection
+	items, isList := object[collection].([]any)
+	if !isList {
+		if budget.Cursor != "" {
+			return nil, &ArgumentError{Fields: []FieldError{{Field: "cursor", Message: "is not a cursor returned for this result"}}}
+		}
+		if len(budget.Fields) > 0 {
+			object = projectFields(object, budget.Fields)
+		}
+		return fitFields(object, budget.MaxBytes), nil
+	}
+
+	offset := 0
+	if budget.Cursor != "" {
+		c, err := decodeCursor(budget.Cursor)
+		if err != nil || c.Collection != collection {
+			return nil, &ArgumentError{Fields: []FieldError{{Field: "cursor", Message: "is not a cursor returned for this result"}}}
+		}
+		offset = min(c.Offset, len(items))
+	}
+	if len(budget.Fields) > 0 {
+		for i, item := range items {
+			if itemObject, ok := item.(map[string]any); ok {
+				items[i] = projectFields(itemObject, budget.Fields)
+			}
+		}
+	}
+
+	total := len(items)
+	remaining := items[offset:]
+	reason := ""
+	if budget.MaxItems > 0 && len(remaining) > budget.MaxItems {
+		remaining = remaining[:budget.MaxItems]
+		reason = "max_items"
+	}
+
+	build := func(count int, reason string) map[string]any {
+		shaped := make(map[string]any, len(object)+1)
+		for key, value := range object {
+			shaped[key] = value
+		}
+		shaped[collection] = remaining[:count]
+		if offset+count < total {
+			shaped[TruncationField] = truncation(collection, offset, count, total, reason)
+		}
+		return shaped
+	}
+
+	shaped := build(len(remaining), reason)
+	if len(remaining) == 0 {
+		// An empty page has no items to leave out, so it is returned even when the rest of the
+		// result exceeds max_bytes.
+		return shaped, nil
+	}
+	if budget.MaxBytes > 0 && jsonSize(shaped) > budget.MaxBytes {
+		// Keep the most items that fit. At least one item is returned so that the cursor advances.
+		count := sort.Search(len(remaining), func(n int) bool {
+			return jsonSize(build(n+1, "max_bytes")) > budget.MaxBytes
+		})
+		shaped = build(min(max(count, 1), len(remaining)), "max_bytes")
+	}
+	return shaped, nil
+}
+
+// fitFields keeps the smallest top level fields of the object that fit in maxBytes together with
+// a summary of the fields left out. Zero means no limit.
+func fitFields(object map[string]any, maxBytes int) map[string]any {
+	if maxBytes <= 0 || jsonSize(object) <= maxBytes {
+		return object
+	}
+	names := make([]string, 0, len(object))
+	sizes := make(map[string]int, len(object))
+	for name, value := range object {
+		names = append(names, name)
+		sizes[name] = jsonSize(value)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if sizes[names[i]] != sizes[names[j]] {
+			return sizes[names[i]] < sizes[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	build := func(count int) map[string]any {
+		shaped := make(map[string]any, count+1)
+		for _, name := range names[:count] {
+			shaped[name] = object[name]
+		}
+		omitted := append([]string{}, names[count:]...)
+		sort.Strings(omitted)
+		shaped[TruncationField] = FieldTruncation{
+			OmittedFields: omitted,
+			Reason:        "max_bytes",
+			Hint: "The result exceeds max_bytes, so the omitted fields were left out. Call the tool again with a larger " +
+				"max_bytes, or select the fields you need with fields, using dots for nested fields.",
+		}
+		return shaped
+	}
+	count := sort.Search(len(names), func(n int) bool {
+		return jsonSize(build(n+1)) > maxBytes
+	})
+	return build(count)
+}
+
+// projectFields keeps the fields of the object named by the dot separated paths.
+func projectFields(object map[string]any, paths []string) map[string]any {
+	nested := map[string][]string{}
+	projected := map[string]any{}
+	for _, path := range paths {
+		field, rest, isNested := strings.Cut(path, ".")
+		value, ok := object[field]
+		if !ok {
+			continue
+		}
+		if !isNested {
+			projected[field] = value
+			continue
+		}
+		nested[field] = append(nested[field], rest)
+	}
+	for field, rest := range nested {
+		if _, whole := projected[field]; whole {
+			continue
+		}
+		switch value := object[field].(type) {
+		case map[string]any:
+			projected[field] = projectFields(value, rest)
+		case []any:
+			list := make([]any, len(value))
+			for i, item := range value {
+				if itemObject, ok := item.(map[string]any); ok {
+					list[i] = projectFields(itemObject, rest)
+				} else {
+					list[i] = item
+				}
+			}
+			projected[field] = list
+		}
+	}
+	return projected
+}
+
+func truncation(collection string, offset, returned, total int, reason string) Truncation {
+	next := encodeCursor(cursor{Collection: collection, Offset: offset + returned})
+	return Truncation{
+		Collection: collection,
+		Offset:     offset,
+		Returned:   returned,
+		Total:      total,
+		Omitted:    total - offset - returned,
+		Reason:     reason,
+		NextCursor: next,
+		Hint: fmt.Sprintf("%d of %d %s were left out. Call the tool again with the same arguments and cursor %q "+
+			"to get the next items, or select fewer fields to fit more items.", total-offset-returned, total, collection, next),
+	}
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
+func jsonSize(value any) int {
+	data, _ := json.Marshal(value)
+	return len(data)
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testListing builds a listing result with count items named item-0, item-1, ...
+func testListing(count int) map[string]any {
+	apps := make([]map[string]any, count)
+	for i := range apps {
+		apps[i] = map[string]any{"id": fmt.Sprintf("id-%d", i), "name": fmt.Sprintf("item-%d", i), "description": strings.Repeat("x", 50)}
+	}
+	return map[string]any{"applications": apps, "total_results": count}
+}
+
+func applyBudget(t *testing.T, value any, budget Budget) map[string]any {
+	t.Helper()
+	result, err := ApplyBudget(value, budget)
+	if err != nil {
+		t.Fatalf("ApplyBudget() error = %v", err)
+	}
+	object, ok := result.(map[string]any)
+	if !ok {
+		t.Fatalf("ApplyBudget() = %T, want an object", result)
+	}
+	return object
+}
+
+func truncationOf(t *testing.T, result map[string]any) Truncation {
+	t.Helper()
+	summary, ok := result[TruncationField].(Truncation)
+	if !ok {
+		t.Fatalf("result has no truncation summary: %v", result)
+	}
+	return summary
+}
+
+func TestApplyBudgetWithinLimits(t *testing.T) {
+	result := applyBudget(t, testListing(3), Budget{Collection: "applications", MaxBytes: 10000})
+	if got := len(result["applications"].([]any)); got != 3 {
+		t.Errorf("returned %d items, want 3", got)
+	}
+	if _, ok := result[TruncationField]; ok {
+		t.Errorf("result within the limits has a truncation summary")
+	}
+}
+
+func TestApplyBudgetMaxItemsAndCursor(t *testing.T) {
+	budget := Budget{Collection: "applications", MaxItems: 2}
+	first := applyBudget(t, testListing(5), budget)
+	summary := truncationOf(t, first)
+	if summary.Returned != 2 || summary.Omitted != 3 || summary.Reason != "max_items" {
+		t.Fatalf("truncation = %+v, want 2 returned, 3 omitted for max_items", summary)
+	}
+
+	budget.Cursor = summary.NextCursor
+	second := applyBudget(t, testListing(5), budget)
+	items := second["applications"].([]any)
+	if got := items[0].(map[string]any)["name"]; got != "item-2" {
+		t.Errorf("next page starts at %v, want item-2", got)
+	}
+}
+
+func TestApplyBudgetMaxBytes(t *testing.T) {
+	budget := Budget{Collection: "applications", MaxBytes: 1000}
+	result := applyBudget(t, testListing(20), budget)
+	if size := jsonSize(result); size > budget.MaxBytes {
+		t.Errorf("result is %d bytes, want at most %d", size, budget.MaxBytes)
+	}
+	summary := truncationOf(t, result)
+	if summary.Returned == 0 || summary.Reason != "max_bytes" {
+		t.Errorf("truncation = %+v, want some items returned for max_bytes", summary)
+	}
+}
+
+func TestApplyBudgetReturnsOneItemBelowMaxBytes(t *testing.T) {
+	result := applyBudget(t, testListing(3), Budget{Collection: "applications", MaxBytes: 10})
+	if got := len(result["applications"].([]any)); got != 1 {
+		t.Errorf("returned %d items, want 1 so that the cursor advances", got)
+	}
+}
+
+func TestApplyBudgetEmptyCollection(t *testing.T) {
+	result := applyBudget(t, testListing(0), Budget{Collection: "applications", MaxBytes: 10})
+	if got := len(result["applications"].([]any)); got != 0 {
+		t.Errorf("returned %d items, want 0", got)
+	}
+	if _, ok := result[TruncationField]; ok {
+		t.Errorf("empty result has a truncation summary")
+	}
+}
+
+func TestApplyBudgetCursorAtEnd(t *testing.T) {
+	end := encodeCursor(cursor{Collection: "applications", Offset: 2})
+	last := applyBudget(t, testListing(2), Budget{Collection: "applications", MaxBytes: 10, Cursor: end})
+	if got := len(last["applications"].([]any)); got != 0 {
+		t.Errorf("returned %d items after the last one, want 0", got)
+	}
+}
+
+func TestApplyBudgetFields(t *testing.T) {
+	result := applyBudget(t, testListing(1), Budget{Collection: "applications", Fields: []string{"name"}})
+	item := result["applications"].([]any)[0].(map[string]any)
+	if len(item) != 1 || item["name"] != "item-0" {
+		t.Errorf("projected item = %v, want only the name", item)
+	}
+}
+
+func TestApplyBudgetWithoutCollection(t *testing.T) {
+	value := map[string]any{"id": "a1", "name": "App", "description": strings.Repeat("x", 500)}
+	result := applyBudget(t, value, Budget{MaxBytes: 400})
+	summary, ok := result[TruncationField].(FieldTruncation)
+	if !ok {
+		t.Fatalf("result has no field truncation summary: %v", result)
+	}
+	if len(summary.OmittedFields) != 1 || summary.OmittedFields[0] != "description" {
+		t.Errorf("omitted fields = %v, want [description]", summary.OmittedFields)
+	}
+	if result["id"] != "a1" || result["name"] != "App" {
+		t.Errorf("result = %v, want id and name kept", result)
+	}
+}
+
+func TestApplyBudgetRejectsForeignCursor(t *testing.T) {
+	cursor := encodeCursor(cursor{Collection: "claims", Offset: 1})
+	for name, budget := range map[string]Budget{
+		"other collection": {Collection: "applications", Cursor: cursor},
+		"malformed":        {Collection: "applications", Cursor: "not a cursor"},
+		"no collection":    {Cursor: cursor},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ApplyBudget(testListing(3), budget)
+			var argErr *ArgumentError
+			if !errors.As(err, &argErr) {
+				t.Errorf("ApplyBudget() error = %v, want an ArgumentError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// leadingColumns are shown first in tables, before the other columns in alphabetical order.
+var leadingColumns = []string{"name", "id"}
+
+// RenderMarkdown renders a JSON value as markdown for chat clients: lists of objects become tables,
+// the scalar fields of an object become a field/value table and nested values get their own section.
+func RenderMarkdown(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	renderMarkdownValue(&b, normalized, 2)
+	return strings.TrimSpace(b.String()), nil
+}
+
+func renderMarkdownValue(b *strings.Builder, value any, level int) {
+	switch v := value.(type) {
+	case map[string]any:
+		renderMarkdownObject(b, v, level)
+	case []any:
+		renderMarkdownList(b, v)
+	default:
+		b.WriteString(markdownCell(v) + "\n\n")
+	}
+}
+
+func renderMarkdownObject(b *strings.Builder, object map[string]any, level int) {
+	var scalars, nested []string
+	for _, key := range orderedKeys(object) {
+		if isNested(object[key]) {
+			nested = append(nested, key)
+		} else {
+			scalars = append(scalars, key)
+		}
+	}
+
+	if len(scalars) > 0 {
+		b.WriteString("| Field | Value |\n|---|---|\n")
+		for _, key := range scalars {
+			fmt.Fprintf(b, "| %s | %s |\n", key, markdownCell(object[key]))
+		}
+		b.WriteString("\n")
+	}
+	for _, key := range nested {
+		fmt.Fprintf(b, "%s %s\n\n", strings.Repeat("#", min(level, 6)), key)
+		renderMarkdownValue(b, object[key], level+1)
+	}
+}
+
+func renderMarkdownList(b *strings.Builder, list []any) {
+	if len(list) == 0 {
+		b.WriteString("_None_\n\n")
+		return
+	}
+
+	columnSet := map[string]any{}
+	for _, item := range list {
+		object, ok := item.(map[string]any)
+		if !ok {
+			// Not a list of objects: render one item per line.
+			for _, item := range list {
+				b.WriteString("- " + markdownCell(item) + "\n")
+			}
+			b.WriteString("\n")
+			return
+		}
+		for key := range object {
+			columnSet[key] = nil
+		}
+	}
+
+	columns := orderedKeys(columnSet)
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	b.WriteString(strings.Repeat("|---", len(columns)) + "|\n")
+	for _, item := range list {
+		object := item.(map[string]any)
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = markdownCell(object[column])
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	b.WriteString("\n")
+}
+
+// isNested reports whether a value needs its own section instead of a table cell.
+func isNested(value any) bool {
+	switch v := value.(type) {
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		for _, item := range v {
+			if _, ok := item.(map[string]any); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func markdownCell(value any) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		text = ""
+	case string:
+		text = v
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = markdownCell(item)
+		}
+		text = strings.Join(items, ", ")
+	case map[string]any:
+		data, _ := json.Marshal(v)
+		text = "`" + string(data) + "`"
+	default:
+		text = fmt.Sprint(v)
+	}
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+func orderedKeys(object map[string]any) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := leadingRank(keys[i]), leadingRank(keys[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func leadingRank(key string) int {
+	for i, column := range leadingColumns {
+		if key == column {
+			return i
+		}
+	}
+	return len(leadingColumns)
+}
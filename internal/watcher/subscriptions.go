@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package watcher
+
+import (
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/asgardeo/mcp/internal/resources"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	methodSubscribe   = "resources/subscribe"
+	methodUnsubscribe = "resources/unsubscribe"
+)
+
+// FilterMessage handles resources/subscribe and resources/unsubscribe requests, which the MCP
+// server library advertises but does not route. The subscription is recorded here and the
+// request is rewritten into a ping with the same ID, whose empty result is also the result of
+// a subscription request. A subscription that is refused is passed on unchanged, so that the
+// server answers it with an error. Other messages are returned unchanged.
+func (w *Watcher) FilterMessage(sessionID string, message []byte) []byte {
+	if sessionID == "" {
+		return message
+	}
+
+	var request struct {
+		JSONRPC string          `json:"jsonrpc"`
+		ID      json.RawMessage `json:"id"`
+		Method  string          `json:"method"`
+		Params  struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || len(request.ID) == 0 {
+		return message
+	}
+	if request.Method != methodSubscribe && request.Method != methodUnsubscribe {
+		return message
+	}
+	if !strings.HasPrefix(request.Params.URI, resources.URIScheme) {
+		return message
+	}
+
+	if request.Method == methodSubscribe {
+		if err := w.Subscribe(sessionID, request.Params.URI); err != nil {
+			log.Printf("Refused the subscription of session %s: %v", sessionID, err)
+			return message
+		}
+	} else {
+		w.Unsubscribe(sessionID, request.Params.URI)
+	}
+
+	ping, err := json.Marshal(map[string]any{
+		"jsonrpc": request.JSONRPC,
+		"id":      request.ID,
+		"method":  mcp.MethodPing,
+	})
+	if err != nil {
+		return message
+	}
+	return ping
+}
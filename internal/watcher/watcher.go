@@ -0,0 +1,342 @@
+/*
+ * Copyright (c) 2025, WSO2 LLC. (http://www.wso2.com).
+ *
+ * WSO2 LLC. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package watcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/resources"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxSubscriptions limits the number of resources a session can subscribe to.
+const maxSubscriptions = 100
+
+// collections are the list resources polled to detect objects being added or removed.
+var collections = []string{
+	resources.URIScheme + "applications",
+	resources.URIScheme + "api-resources",
+	resources.URIScheme + "claims",
+	resources.URIScheme + "claims/local",
+}
+
+// snapshot is what was read from a resource on the last poll.
+type snapshot struct {
+	hash    [sha256.Size]byte
+	members string
+}
+
+// Watcher polls the resources subscribed to by the sessions and notifies them of changes.
+// Snapshots are kept per profile, since sessions may be bound to different organizations.
+type Watcher struct {
+	interval time.Duration
+	trigger  chan struct{}
+
+	mu            sync.Mutex
+	server        *server.MCPServer
+	subscriptions map[string]map[string]bool // session ID -> subscribed URIs
+	snapshots     map[string]snapshot        // profile + " " + URI -> last snapshot
+}
+
+// New creates a watcher that polls the subscribed resources at the given interval.
+// A zero interval disables polling, but changes made through the server's own tools are still notified.
+// Nothing is polled when the tools act on behalf of the caller, since the watcher has no caller
+// whose credentials it could read the resources with.
+func New(interval time.Duration) *Watcher {
+	return &Watcher{
+		interval:      interval,
+		trigger:       make(chan struct{}, 1),
+		subscriptions: map[string]map[string]bool{},
+		snapshots:     map[string]snapshot{},
+	}
+}
+
+// Run polls the subscribed resources of the server until the context is cancelled.
+func (w *Watcher) Run(ctx context.Context, s *server.MCPServer) {
+	w.mu.Lock()
+	w.server = s
+	w.mu.Unlock()
+	if asgardeo.DelegationEnabled() {
+		log.Printf("Resource subscriptions are disabled, since resources are only read on behalf of the caller")
+		return
+	}
+
+	var tick <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-tick:
+		case <-w.trigger:
+		case <-ctx.Done():
+			return
+		}
+		w.poll(ctx)
+	}
+}
+
+// Refresh polls the subscribed resources right away, without waiting for the next interval.
+func (w *Watcher) Refresh() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Subscribe records that the session wants to be notified when the resource changes. Only the
+// resources of the server can be subscribed to, up to maxSubscriptions per session.
+func (w *Watcher) Subscribe(sessionID, uri string) error {
+	if asgardeo.DelegationEnabled() {
+		return errors.New("resource subscriptions are not available when acting on behalf of the caller")
+	}
+	if !resources.IsResourceURI(uri) {
+		return fmt.Errorf("%s is not a resource of the server", uri)
+	}
+
+	w.mu.Lock()
+	subscribed := w.subscriptions[sessionID]
+	if subscribed == nil {
+		subscribed = map[string]bool{}
+		w.subscriptions[sessionID] = subscribed
+	}
+	if !subscribed[uri] && len(subscribed) >= maxSubscriptions {
+		w.mu.Unlock()
+		return fmt.Errorf("the session is already subscribed to %d resources", maxSubscriptions)
+	}
+	subscribed[uri] = true
+	w.mu.Unlock()
+
+	// Takes the first snapshot, so that changes made before the next interval are noticed.
+	w.Refresh()
+	return nil
+}
+
+// Unsubscribe stops notifying the session of changes to the resource.
+func (w *Watcher) Unsubscribe(sessionID, uri string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subscriptions[sessionID], uri)
+	if len(w.subscriptions[sessionID]) == 0 {
+		delete(w.subscriptions, sessionID)
+	}
+}
+
+// ForgetSession drops the subscriptions of a session that has ended.
+func (w *Watcher) ForgetSession(_ context.Context, session server.ClientSession) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subscriptions, session.SessionID())
+}
+
+// ToolMiddleware refreshes the subscribed resources after a tool that modifies the organization succeeds.
+func (w *Watcher) ToolMiddleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		if err != nil || result == nil || result.IsError {
+			return result, err
+		}
+		if s := server.ServerFromContext(ctx); s != nil {
+			if tool := s.GetTool(req.Params.Name); tool != nil && !isReadOnly(tool.Tool) {
+				w.Refresh()
+			}
+		}
+		return result, err
+	}
+}
+
+// poll reads the subscribed resources and the collections for every profile in use by a
+// subscribed session, and notifies the sessions of the resources that changed since the last poll.
+// The snapshots of resources no session subscribes to any more are dropped.
+func (w *Watcher) poll(ctx context.Context) {
+	if asgardeo.DelegationEnabled() {
+		return
+	}
+
+	w.mu.Lock()
+	s := w.server
+	sessionsByProfile := map[string]map[string][]string{} // profile -> session ID -> URIs
+	for sessionID, uris := range w.subscriptions {
+		profile, err := asgardeo.SessionProfile(sessionID)
+		if err != nil {
+			log.Printf("Error resolving the profile of session %s: %v", sessionID, err)
+			continue
+		}
+		if sessionsByProfile[profile] == nil {
+			sessionsByProfile[profile] = map[string][]string{}
+		}
+		for uri := range uris {
+			sessionsByProfile[profile][sessionID] = append(sessionsByProfile[profile][sessionID], uri)
+		}
+	}
+	w.mu.Unlock()
+
+	urisByProfile := map[string]map[string]bool{}
+	for profile, sessions := range sessionsByProfile {
+		uris := map[string]bool{}
+		for _, uri := range collections {
+			uris[uri] = true
+		}
+		for _, subscribed := range sessions {
+			for _, uri := range subscribed {
+				uris[uri] = true
+			}
+		}
+		urisByProfile[profile] = uris
+	}
+	w.pruneSnapshots(urisByProfile)
+
+	for profile, sessions := range sessionsByProfile {
+		uris := urisByProfile[profile]
+
+		listChanged := false
+		for uri := range uris {
+			updated, membersChanged := w.check(asgardeo.WithProfile(ctx, profile), s, profile, uri)
+			listChanged = listChanged || membersChanged
+			if !updated {
+				continue
+			}
+			for sessionID, subscribed := range sessions {
+				for _, subscribedURI := range subscribed {
+					if subscribedURI == uri {
+						notify(s, sessionID, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+					}
+				}
+			}
+		}
+		if listChanged {
+			for sessionID := range sessions {
+				notify(s, sessionID, mcp.MethodNotificationResourcesListChanged, nil)
+			}
+		}
+	}
+}
+
+// pruneSnapshots drops the snapshots of the resources that are not polled for their profile any more.
+func (w *Watcher) pruneSnapshots(urisByProfile map[string]map[string]bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key := range w.snapshots {
+		profile, uri, _ := strings.Cut(key, " ")
+		if !urisByProfile[profile][uri] {
+			delete(w.snapshots, key)
+		}
+	}
+}
+
+// check reads the resource and compares it with the previous snapshot. It reports whether
+// the resource changed and, for collections, whether objects were added or removed.
+func (w *Watcher) check(ctx context.Context, s *server.MCPServer, profile, uri string) (updated, membersChanged bool) {
+	text, err := readResource(ctx, s, uri)
+	if err != nil {
+		log.Printf("Error polling resource %s of profile %q: %v", uri, profile, err)
+		return false, false
+	}
+	current := snapshot{hash: sha256.Sum256([]byte(text)), members: members(text)}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	key := profile + " " + uri
+	previous, seen := w.snapshots[key]
+	w.snapshots[key] = current
+	if !seen {
+		return false, false
+	}
+	return previous.hash != current.hash, previous.members != current.members
+}
+
+// readResource reads the resource through the server, so that the same handlers serve
+// the clients and the watcher.
+func readResource(ctx context.Context, s *server.MCPServer, uri string) (string, error) {
+	request, err := json.Marshal(mcp.JSONRPCRequest{
+		JSONRPC: mcp.JSONRPC_VERSION,
+		ID:      mcp.NewRequestId("watcher"),
+		Request: mcp.Request{Method: string(mcp.MethodResourcesRead)},
+		Params:  mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		return "", err
+	}
+	response, err := json.Marshal(s.HandleMessage(ctx, request))
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Result struct {
+			Contents []struct {
+				Text string `json:"text"`
+			} `json:"contents"`
+		} `json:"result"`
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		return "", err
+	}
+	if result.Error != nil {
+		return "", errors.New(result.Error.Message)
+	}
+	texts := []string{}
+	for _, content := range result.Result.Contents {
+		texts = append(texts, content.Text)
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// members returns the sorted IDs of the objects of a collection, or an empty string if the
+// resource is not a list of objects.
+func members(text string) string {
+	var items []map[string]any
+	if err := json.Unmarshal([]byte(text), &items); err != nil {
+		return ""
+	}
+	ids := []string{}
+	for _, item := range items {
+		if id, ok := item["id"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func notify(s *server.MCPServer, sessionID string, method mcp.MCPMethod, params map[string]any) {
+	if err := s.SendNotificationToSpecificClient(sessionID, string(method), params); err != nil {
+		log.Printf("Error notifying session %s of %s: %v", sessionID, method, err)
+	}
+}
+
+func isReadOnly(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
@@ -19,87 +19,330 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/asgardeo/mcp/internal/asgardeo"
+	"github.com/asgardeo/mcp/internal/config"
+	"github.com/asgardeo/mcp/internal/prompts"
+	"github.com/asgardeo/mcp/internal/resources"
 	"github.com/asgardeo/mcp/internal/tools"
+	"github.com/asgardeo/mcp/internal/transport"
+	"github.com/asgardeo/mcp/internal/watcher"
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
-// setupServer configures the MCP server and registers tools.
-func setupServer() *server.MCPServer {
+// toolRegistry registers the tools selected by the tool filter.
+type toolRegistry struct {
+	server *server.MCPServer
+	filter config.ToolFilter
+}
+
+// add registers a tool that runs against the Asgardeo organization, exposing the profile selector.
+func (r toolRegistry) add(category string, tool mcp.Tool, handler server.ToolHandlerFunc) {
+	r.register(category, tools.WithProfileArgument(tool), handler)
+}
+
+// register registers a tool with the output format selector, unless the tool filter excludes it.
+func (r toolRegistry) register(category string, tool mcp.Tool, handler server.ToolHandlerFunc) {
+	tool = tools.WithOutputFormatArgument(tool)
+	readOnly := tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+	if !r.filter.Allows(tool.Name, category, readOnly) {
+		log.Printf("Tool %s is disabled by the tool filter", tool.Name)
+		return
+	}
+	r.server.AddTool(tool, handler)
+}
+
+// setupServer configures the MCP server and registers the tools selected by the filter.
+// The watcher notifies the sessions subscribed to resources of changes.
+func setupServer(filter config.ToolFilter, resourceWatcher *watcher.Watcher) *server.MCPServer {
+	hooks := &server.Hooks{}
+	hooks.AddOnUnregisterSession(asgardeo.ForgetSession)
+	hooks.AddOnUnregisterSession(resourceWatcher.ForgetSession)
+
 	s := server.NewMCPServer(
 		"Asgardeo Management MCP",
 		"0.0.1",
 		server.WithResourceCapabilities(true, true),
 		server.WithToolCapabilities(true),
+		server.WithPromptCapabilities(false),
 		server.WithLogging(),
 		server.WithRecovery(),
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(tools.ProfileMiddleware),
+		server.WithToolHandlerMiddleware(tools.OutputMiddleware),
+		server.WithToolHandlerMiddleware(resourceWatcher.ToolMiddleware),
 	)
+	registry := toolRegistry{server: s, filter: filter}
 
 	appListTool, appListToolImpl := tools.GetListApplicationsTool()
-	s.AddTool(appListTool, appListToolImpl)
+	registry.add(config.ToolCategories.Applications, appListTool, appListToolImpl)
 
 	spaTool, spaToolImpl := tools.GetCreateSinglePageAppTool()
-	s.AddTool(spaTool, spaToolImpl)
+	registry.add(config.ToolCategories.Applications, spaTool, spaToolImpl)
 
 	webAppTool, webAppToolImpl := tools.GetCreateWebAppWithSSRTool()
-	s.AddTool(webAppTool, webAppToolImpl)
+	registry.add(config.ToolCategories.Applications, webAppTool, webAppToolImpl)
 
 	mobileAppTool, mobileAppToolImpl := tools.GetCreateMobileAppTool()
-	s.AddTool(mobileAppTool, mobileAppToolImpl)
+	registry.add(config.ToolCategories.Applications, mobileAppTool, mobileAppToolImpl)
 
 	m2mAppTool, m2mAppToolImpl := tools.GetCreateM2MAppTool()
-	s.AddTool(m2mAppTool, m2mAppToolImpl)
+	registry.add(config.ToolCategories.Applications, m2mAppTool, m2mAppToolImpl)
+
+	oidcAppTool, oidcAppToolImpl := tools.GetCreateOIDCApplicationTool()
+	registry.add(config.ToolCategories.Applications, oidcAppTool, oidcAppToolImpl)
+
+	samlAppTool, samlAppToolImpl := tools.GetCreateSAMLApplicationTool()
+	registry.add(config.ToolCategories.Applications, samlAppTool, samlAppToolImpl)
 
 	getAppByNameTool, getAppByNameToolmpl := tools.GetSearchApplicationByNameTool()
-	s.AddTool(getAppByNameTool, getAppByNameToolmpl)
+	registry.add(config.ToolCategories.Applications, getAppByNameTool, getAppByNameToolmpl)
 
 	getAppByClientIdTool, getAppByClientIdToolmpl := tools.GetSearchApplicationByClientIdTool()
-	s.AddTool(getAppByClientIdTool, getAppByClientIdToolmpl)
+	registry.add(config.ToolCategories.Applications, getAppByClientIdTool, getAppByClientIdToolmpl)
+
+	getAppTool, getAppToolImpl := tools.GetApplicationTool()
+	registry.add(config.ToolCategories.Applications, getAppTool, getAppToolImpl)
+
+	deleteAppTool, deleteAppToolImpl := tools.GetDeleteApplicationTool()
+	registry.add(config.ToolCategories.Applications, deleteAppTool, deleteAppToolImpl)
+
+	regenerateSecretTool, regenerateSecretToolImpl := tools.GetRegenerateClientSecretTool()
+	registry.add(config.ToolCategories.Applications, regenerateSecretTool, regenerateSecretToolImpl)
+
+	revokeAppTool, revokeAppToolImpl := tools.GetRevokeApplicationTool()
+	registry.add(config.ToolCategories.Applications, revokeAppTool, revokeAppToolImpl)
+
+	reactivateAppTool, reactivateAppToolImpl := tools.GetReactivateApplicationTool()
+	registry.add(config.ToolCategories.Applications, reactivateAppTool, reactivateAppToolImpl)
 
 	getAppUpdateTool, getAppUpdateToolImpl := tools.GetUpdateApplicationBasicInfoTool()
-	s.AddTool(getAppUpdateTool, getAppUpdateToolImpl)
+	registry.add(config.ToolCategories.Applications, getAppUpdateTool, getAppUpdateToolImpl)
 
 	getAppOAuthConfigUpdateTool, getAppUpdateOAuthConfigToolImpl := tools.GetUpdateApplicationOAuthConfigTool()
-	s.AddTool(getAppOAuthConfigUpdateTool, getAppUpdateOAuthConfigToolImpl)
+	registry.add(config.ToolCategories.Applications, getAppOAuthConfigUpdateTool, getAppUpdateOAuthConfigToolImpl)
+
+	updateSAMLAppTool, updateSAMLAppToolImpl := tools.GetUpdateSAMLApplicationTool()
+	registry.add(config.ToolCategories.Applications, updateSAMLAppTool, updateSAMLAppToolImpl)
+
+	samlIdPMetadataTool, samlIdPMetadataToolImpl := tools.GetSAMLIdPMetadataTool()
+	registry.add(config.ToolCategories.Applications, samlIdPMetadataTool, samlIdPMetadataToolImpl)
 
 	updateApplicationClaimConfigTool, updateApplicationClaimConfigToolImpl := tools.GetUpdateApplicationClaimConfigTool()
-	s.AddTool(updateApplicationClaimConfigTool, updateApplicationClaimConfigToolImpl)
+	registry.add(config.ToolCategories.Applications, updateApplicationClaimConfigTool, updateApplicationClaimConfigToolImpl)
 
 	authorizeAPITool, authorizeAPIToolImpl := tools.GetAuthorizeAPITool()
-	s.AddTool(authorizeAPITool, authorizeAPIToolImpl)
+	registry.add(config.ToolCategories.Applications, authorizeAPITool, authorizeAPIToolImpl)
 
 	authorizedAPIListTool, authorizedAPIListToolImpl := tools.GetListAuthorizedAPITool()
-	s.AddTool(authorizedAPIListTool, authorizedAPIListToolImpl)
+	registry.add(config.ToolCategories.Applications, authorizedAPIListTool, authorizedAPIListToolImpl)
 
 	updateLoginFlowTool, updateLoginFlowToolImpl := tools.GetUpdateLoginFlowTool()
-	s.AddTool(updateLoginFlowTool, updateLoginFlowToolImpl)
+	registry.add(config.ToolCategories.Applications, updateLoginFlowTool, updateLoginFlowToolImpl)
 
 	apiResourceListTool, apiResourceListToolImpl := tools.GetListAPIResourcesTool()
-	s.AddTool(apiResourceListTool, apiResourceListToolImpl)
+	registry.add(config.ToolCategories.APIResources, apiResourceListTool, apiResourceListToolImpl)
 
 	apiResourceListByNameTool, apiResourceListByNameToolImpl := tools.GetSearchAPIResourcesByNameTool()
-	s.AddTool(apiResourceListByNameTool, apiResourceListByNameToolImpl)
+	registry.add(config.ToolCategories.APIResources, apiResourceListByNameTool, apiResourceListByNameToolImpl)
 
 	apiResourceSearchByIdentifierTool, apiResourceSearchByIdentifierToolImpl := tools.GetSearchAPIResourceByIdentifierTool()
-	s.AddTool(apiResourceSearchByIdentifierTool, apiResourceSearchByIdentifierToolImpl)
+	registry.add(config.ToolCategories.APIResources, apiResourceSearchByIdentifierTool, apiResourceSearchByIdentifierToolImpl)
 
 	apiResourceCreateTool, apiResourceCreateToolImpl := tools.GetCreateAPIResourceTool()
-	s.AddTool(apiResourceCreateTool, apiResourceCreateToolImpl)
+	registry.add(config.ToolCategories.APIResources, apiResourceCreateTool, apiResourceCreateToolImpl)
 
 	userCreateTool, userCreateToolImpl := tools.GetCreateUserTool()
-	s.AddTool(userCreateTool, userCreateToolImpl)
+	registry.add(config.ToolCategories.Users, userCreateTool, userCreateToolImpl)
 
 	listClaimsTool, listClaimsToolImpl := tools.GetListClaimsTool()
-	s.AddTool(listClaimsTool, listClaimsToolImpl)
+	registry.add(config.ToolCategories.Claims, listClaimsTool, listClaimsToolImpl)
+
+	reloadConfigurationTool, reloadConfigurationToolImpl := tools.GetReloadConfigurationTool()
+	registry.add(config.ToolCategories.Server, reloadConfigurationTool, reloadConfigurationToolImpl)
+
+	listProfilesTool, listProfilesToolImpl := tools.GetListProfilesTool()
+	registry.register(config.ToolCategories.Server, listProfilesTool, listProfilesToolImpl)
+
+	setDefaultProfileTool, setDefaultProfileToolImpl := tools.GetSetDefaultProfileTool()
+	registry.register(config.ToolCategories.Server, setDefaultProfileTool, setDefaultProfileToolImpl)
+
+	applicationListResource, applicationListResourceImpl := resources.GetApplicationListResource()
+	s.AddResource(applicationListResource, applicationListResourceImpl)
+
+	applicationTemplate, applicationTemplateImpl := resources.GetApplicationResourceTemplate()
+	s.AddResourceTemplate(applicationTemplate, applicationTemplateImpl)
+
+	apiResourceListResource, apiResourceListResourceImpl := resources.GetAPIResourceListResource()
+	s.AddResource(apiResourceListResource, apiResourceListResourceImpl)
+
+	apiResourceTemplate, apiResourceTemplateImpl := resources.GetAPIResourceResourceTemplate()
+	s.AddResourceTemplate(apiResourceTemplate, apiResourceTemplateImpl)
+
+	claimDialectListResource, claimDialectListResourceImpl := resources.GetClaimDialectListResource()
+	s.AddResource(claimDialectListResource, claimDialectListResourceImpl)
+
+	claimListTemplate, claimListTemplateImpl := resources.GetClaimListResourceTemplate()
+	s.AddResourceTemplate(claimListTemplate, claimListTemplateImpl)
+
+	claimTemplate, claimTemplateImpl := resources.GetClaimResourceTemplate()
+	s.AddResourceTemplate(claimTemplate, claimTemplateImpl)
+
+	for _, promptTemplate := range prompts.Templates() {
+		prompt, promptImpl, err := promptTemplate.GetPrompt()
+		if err != nil {
+			log.Printf("Skipping prompt: %v", err)
+			continue
+		}
+		s.AddPrompt(prompt, promptImpl)
+	}
 
 	return s
 }
 
+// options holds the settings given on the command line. The transport flags are kept apart,
+// since the transport settings can only be loaded once the configuration file is.
+type options struct {
+	transport    config.TransportConfig
+	setFlags     map[string]bool
+	configFile   string
+	readOnly     bool
+	pollInterval time.Duration
+}
+
+// parseFlags reads the command line.
+func parseFlags() options {
+	cfg := config.TransportConfig{
+		Mode:              config.TransportModes.Stdio,
+		Addr:              config.DEFAULT_HTTP_ADDR,
+		BasePath:          config.DEFAULT_HTTP_BASE_PATH,
+		HeartbeatInterval: config.DEFAULT_HTTP_HEARTBEAT_INTERVAL,
+		ShutdownTimeout:   config.DEFAULT_HTTP_SHUTDOWN_TIMEOUT,
+		Auth:              config.AuthConfig{Enabled: true},
+	}
+	opts := options{setFlags: map[string]bool{}}
+	flag.StringVar(&opts.configFile, "config", os.Getenv(config.CONFIG_FILE_PARAM), "Path of the YAML or JSON configuration file (default: config.yaml in the user configuration directory)")
+	flag.BoolVar(&opts.readOnly, "read-only", false, "Register only the tools that do not modify the organization (overrides READ_ONLY)")
+	flag.DurationVar(&opts.pollInterval, "resource-poll-interval", config.DEFAULT_RESOURCE_POLL_INTERVAL, "Interval at which subscribed resources are polled for changes, 0 to disable (overrides RESOURCE_POLL_INTERVAL)")
+	flag.StringVar(&cfg.Mode, "transport", cfg.Mode, "Transport to serve MCP over: stdio, http (Streamable HTTP with SSE fallback) or sse")
+	flag.StringVar(&cfg.Addr, "addr", cfg.Addr, "Listen address for the http and sse transports")
+	flag.StringVar(&cfg.BasePath, "base-path", cfg.BasePath, "Base path of the MCP endpoint for the http and sse transports")
+	flag.BoolVar(&cfg.Stateless, "stateless", cfg.Stateless, "Disable MCP session tracking for the http transport")
+	flag.DurationVar(&cfg.HeartbeatInterval, "heartbeat-interval", cfg.HeartbeatInterval, "Interval between keep-alive messages on open streams, 0 to disable")
+	flag.DurationVar(&cfg.ShutdownTimeout, "shutdown-timeout", cfg.ShutdownTimeout, "Time to wait for in-flight requests during graceful shutdown")
+	flag.BoolVar(&cfg.Auth.Enabled, "auth", cfg.Auth.Enabled, "Require OAuth 2.1 bearer tokens on the http and sse transports")
+	flag.BoolVar(&cfg.Auth.Insecure, "insecure-no-auth", cfg.Auth.Insecure, "Serve the http and sse transports without authentication, letting anyone who can reach them use the tools")
+	flag.StringVar(&cfg.Auth.JWKSURL, "auth-jwks-url", cfg.Auth.JWKSURL, "JWKS URL used to verify bearer token signatures")
+	flag.StringVar(&cfg.Auth.Issuer, "auth-issuer", cfg.Auth.Issuer, "Expected issuer of bearer tokens")
+	flag.StringVar(&cfg.Auth.Audience, "auth-audience", cfg.Auth.Audience, "Expected audience of bearer tokens")
+	flag.StringVar(&cfg.Auth.ResourceURL, "auth-resource-url", cfg.Auth.ResourceURL, "Resource identifier advertised in the protected resource metadata")
+	flag.BoolVar(&cfg.Delegation.Enabled, "token-exchange", cfg.Delegation.Enabled, "Act on behalf of the authenticated caller by exchanging their token for a management API token")
+	flag.Parse()
+	flag.Visit(func(f *flag.Flag) { opts.setFlags[f.Name] = true })
+	opts.transport = cfg
+	return opts
+}
+
+// transportConfig loads the transport settings, whose defaults depend on the configuration file,
+// and lets the flags given on the command line override them.
+func (o options) transportConfig() config.TransportConfig {
+	cfg := config.LoadTransport()
+	flags := o.transport
+	overrides := map[string]func(){
+		"transport":          func() { cfg.Mode = flags.Mode },
+		"addr":               func() { cfg.Addr = flags.Addr },
+		"base-path":          func() { cfg.BasePath = flags.BasePath },
+		"stateless":          func() { cfg.Stateless = flags.Stateless },
+		"heartbeat-interval": func() { cfg.HeartbeatInterval = flags.HeartbeatInterval },
+		"shutdown-timeout":   func() { cfg.ShutdownTimeout = flags.ShutdownTimeout },
+		"auth":               func() { cfg.Auth.Enabled = flags.Auth.Enabled },
+		"insecure-no-auth":   func() { cfg.Auth.Insecure = flags.Auth.Insecure },
+		"auth-jwks-url":      func() { cfg.Auth.JWKSURL = flags.Auth.JWKSURL },
+		"auth-issuer":        func() { cfg.Auth.Issuer = flags.Auth.Issuer },
+		"auth-audience":      func() { cfg.Auth.Audience = flags.Auth.Audience },
+		"auth-resource-url":  func() { cfg.Auth.ResourceURL = flags.Auth.ResourceURL },
+		"token-exchange":     func() { cfg.Delegation.Enabled = flags.Delegation.Enabled },
+	}
+	for name, override := range overrides {
+		if o.setFlags[name] {
+			override()
+		}
+	}
+	return cfg
+}
+
+// resourcePollInterval returns the poll interval of the flag when it was given, and otherwise the
+// one configured in the environment or the configuration file.
+func (o options) resourcePollInterval() time.Duration {
+	if o.setFlags["resource-poll-interval"] {
+		return o.pollInterval
+	}
+	return config.GetResourcePollInterval()
+}
+
+// reloadOnHangup reloads the client configuration whenever the process receives SIGHUP.
+func reloadOnHangup(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+	for {
+		select {
+		case <-hangup:
+			log.Printf("Received SIGHUP, reloading configuration")
+			_, _ = asgardeo.Reload(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func main() {
+	opts := parseFlags()
+	if err := config.LoadFile(opts.configFile); err != nil {
+		log.Fatalf("%v", err)
+	}
+	transportConfig := opts.transportConfig()
+	if err := config.ConfigureLogging(); err != nil {
+		log.Fatalf("Invalid logging configuration: %v", err)
+	}
+	if err := transportConfig.Validate(); err != nil {
+		log.Fatalf("Invalid transport configuration: %v", err)
+	}
+	config.SetActiveTransportMode(transportConfig.Mode)
+	if transportConfig.Mode != config.TransportModes.Stdio && config.LoadSecretPolicy().Sink == config.SecretSinks.Stdout {
+		log.Printf("WARNING: the stdout secret sink is only available with the stdio transport; tools that issue secrets need another secret_sink")
+	}
+
+	if transportConfig.Mode != config.TransportModes.Stdio && transportConfig.Delegation.Enabled {
+		asgardeo.EnableDelegation(transportConfig.Delegation)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go reloadOnHangup(ctx)
+
+	toolFilter := config.LoadToolFilter()
+	if opts.readOnly {
+		toolFilter.ReadOnly = true
+	}
+	if err := toolFilter.Validate(); err != nil {
+		log.Fatalf("Invalid tool filter: %v", err)
+	}
+
 	// Setup and start MCP server
-	s := setupServer()
-	if err := server.ServeStdio(s); err != nil {
+	resourceWatcher := watcher.New(opts.resourcePollInterval())
+	s := setupServer(toolFilter, resourceWatcher)
+	go resourceWatcher.Run(ctx, s)
+	if err := transport.Serve(ctx, s, transportConfig, resourceWatcher.FilterMessage); err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }